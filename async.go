@@ -0,0 +1,250 @@
+package deepl
+
+import (
+	"context"
+	"io"
+
+	"github.com/anthdm/tasker"
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// TranslateTextAsync returns a task that can be awaited to translate texts, see TranslateText.
+func (d *Translator) TranslateTextAsync(
+	text []string,
+	sourceLang consts.SourceLang,
+	targetLang consts.TargetLang,
+	opts ...func(*types.TextTranslateOptions) error,
+) tasker.TaskFunc[[]types.Translation] {
+	return func(ctx context.Context) ([]types.Translation, error) {
+		return d.TranslateText(ctx, text, sourceLang, targetLang, opts...)
+	}
+}
+
+// TranslateDocumentAsync translates a document and returns a task that can be awaited, see TranslateDocument.
+func (d *Translator) TranslateDocumentAsync(
+	s consts.SourceLang,
+	t consts.TargetLang,
+	f io.Reader,
+	w io.Writer,
+	opts ...func(*types.DocumentTranslateOptions) error,
+) tasker.TaskFunc[types.DocumentStatus] {
+	return func(ctx context.Context) (types.DocumentStatus, error) {
+		return d.TranslateDocument(ctx, s, t, f, w, opts...)
+	}
+}
+
+// TranslateDocumentsAsync returns a task that can be awaited to translate a batch of documents, see TranslateDocuments.
+func (d *Translator) TranslateDocumentsAsync(
+	inputs []types.DocumentInput,
+	s consts.SourceLang,
+	targets []consts.TargetLang,
+	opts ...func(*types.BatchTranslateOptions) error,
+) tasker.TaskFunc[[]types.DocumentResult] {
+	return func(ctx context.Context) ([]types.DocumentResult, error) {
+		return d.TranslateDocuments(ctx, inputs, s, targets, opts...)
+	}
+}
+
+// UploadDocumentAsync returns a task that can be awaited to upload a document, see UploadDocument.
+func (d *Translator) UploadDocumentAsync(
+	s consts.SourceLang,
+	t consts.TargetLang,
+	f io.Reader,
+	opts ...func(*types.DocumentTranslateOptions) error,
+) tasker.TaskFunc[types.DocumentHandle] {
+	return func(ctx context.Context) (types.DocumentHandle, error) {
+		return d.UploadDocument(ctx, s, t, f, opts...)
+	}
+}
+
+// GetDocumentStatusAsync returns a task that can be awaited to get the status of a document translation.
+func (d *Translator) GetDocumentStatusAsync(doc types.DocumentHandle) tasker.TaskFunc[types.DocumentStatus] {
+	return func(ctx context.Context) (types.DocumentStatus, error) {
+		return d.GetDocumentStatus(ctx, doc)
+	}
+}
+
+// WaitUntilDocumentTranslationFinishedAsync returns a task that can be awaited until a document translation is done.
+func (d *Translator) WaitUntilDocumentTranslationFinishedAsync(doc types.DocumentHandle) tasker.TaskFunc[types.DocumentStatus] {
+	return func(ctx context.Context) (types.DocumentStatus, error) {
+		return d.WaitUntilDocumentTranslationFinished(ctx, doc)
+	}
+}
+
+// DownloadDocumentAsync returns a task that can be awaited to download a translated document.
+func (d *Translator) DownloadDocumentAsync(doc types.DocumentHandle, w io.Writer) tasker.TaskFunc[bool] {
+	return func(ctx context.Context) (bool, error) {
+		if err := d.DownloadDocument(ctx, doc, w); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// CreateGlossaryAsync returns a task that can be awaited to create a glossary, see CreateGlossary.
+func (d *Translator) CreateGlossaryAsync(
+	name string,
+	source consts.SourceLang,
+	target consts.TargetLang,
+	glossary GlossaryEntries,
+	opts ...func(*types.GlossaryOptions) error,
+) tasker.TaskFunc[types.Glossary] {
+	return func(ctx context.Context) (types.Glossary, error) {
+		return d.CreateGlossary(ctx, name, source, target, glossary, opts...)
+	}
+}
+
+// GetGlossariesAsync returns a task that can be awaited to list all glossaries.
+func (d *Translator) GetGlossariesAsync() tasker.TaskFunc[[]types.Glossary] {
+	return func(ctx context.Context) ([]types.Glossary, error) {
+		return d.GetGlossaries(ctx)
+	}
+}
+
+// GetGlossaryDetailsAsync returns a task that can be awaited to get a glossary.
+func (d *Translator) GetGlossaryDetailsAsync(id string) tasker.TaskFunc[types.Glossary] {
+	return func(ctx context.Context) (types.Glossary, error) {
+		return d.GetGlossaryDetails(ctx, id)
+	}
+}
+
+// GetGlossaryEntriesAsync returns a task that can be awaited to get the entries of a glossary.
+func (d *Translator) GetGlossaryEntriesAsync(id string) tasker.TaskFunc[GlossaryEntries] {
+	return func(ctx context.Context) (GlossaryEntries, error) {
+		return d.GetGlossaryEntries(ctx, id)
+	}
+}
+
+// DeleteGlossaryAsync returns a task that can be awaited to delete a glossary.
+func (d *Translator) DeleteGlossaryAsync(id string) tasker.TaskFunc[bool] {
+	return func(ctx context.Context) (bool, error) {
+		if err := d.DeleteGlossary(ctx, id); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// GetUsageAsync returns a task that can be awaited to get the current usage of the DeepL API.
+func (d *Translator) GetUsageAsync() tasker.TaskFunc[types.Usage] {
+	return func(ctx context.Context) (types.Usage, error) {
+		return d.GetUsage(ctx)
+	}
+}
+
+// GetLanguagesAsync returns a task that can be awaited to get the supported languages of the DeepL API.
+// The languageType parameter can be either "source" or "target".
+func (d *Translator) GetLanguagesAsync(languageType string) tasker.TaskFunc[[]types.SupportedLanguage] {
+	return func(ctx context.Context) ([]types.SupportedLanguage, error) {
+		return d.GetLanguages(ctx, languageType)
+	}
+}
+
+// GetGlossaryLanguagesAsync returns a task that can be awaited to get the language pairs supported for glossaries.
+func (d *Translator) GetGlossaryLanguagesAsync() tasker.TaskFunc[types.GlossaryLanguagePairs] {
+	return func(ctx context.Context) (types.GlossaryLanguagePairs, error) {
+		return d.GetGlossaryLanguages(ctx)
+	}
+}
+
+// GetLanguageCatalogAsync returns a task that can be awaited to get the language catalog, see GetLanguageCatalog.
+func (d *Translator) GetLanguageCatalogAsync() tasker.TaskFunc[*LanguageCatalog] {
+	return func(ctx context.Context) (*LanguageCatalog, error) {
+		return d.GetLanguageCatalog(ctx)
+	}
+}
+
+// CreateMultilingualGlossaryAsync returns a task that can be awaited to create a multilingual glossary,
+// see CreateMultilingualGlossary.
+func (d *Translator) CreateMultilingualGlossaryAsync(name string, dictionaries []GlossaryDictionary) tasker.TaskFunc[types.MultilingualGlossary] {
+	return func(ctx context.Context) (types.MultilingualGlossary, error) {
+		return d.CreateMultilingualGlossary(ctx, name, dictionaries)
+	}
+}
+
+// GetMultilingualGlossariesAsync returns a task that can be awaited to list all multilingual glossaries.
+func (d *Translator) GetMultilingualGlossariesAsync() tasker.TaskFunc[[]types.MultilingualGlossary] {
+	return func(ctx context.Context) ([]types.MultilingualGlossary, error) {
+		return d.GetMultilingualGlossaries(ctx)
+	}
+}
+
+// GetMultilingualGlossaryAsync returns a task that can be awaited to get a multilingual glossary.
+func (d *Translator) GetMultilingualGlossaryAsync(id string) tasker.TaskFunc[types.MultilingualGlossary] {
+	return func(ctx context.Context) (types.MultilingualGlossary, error) {
+		return d.GetMultilingualGlossary(ctx, id)
+	}
+}
+
+// UpdateMultilingualGlossaryAsync returns a task that can be awaited to update a multilingual glossary,
+// see UpdateMultilingualGlossary.
+func (d *Translator) UpdateMultilingualGlossaryAsync(
+	id string,
+	name string,
+	dictionaries []GlossaryDictionary,
+) tasker.TaskFunc[types.MultilingualGlossary] {
+	return func(ctx context.Context) (types.MultilingualGlossary, error) {
+		return d.UpdateMultilingualGlossary(ctx, id, name, dictionaries)
+	}
+}
+
+// DeleteMultilingualGlossaryAsync returns a task that can be awaited to delete a multilingual glossary.
+func (d *Translator) DeleteMultilingualGlossaryAsync(id string) tasker.TaskFunc[bool] {
+	return func(ctx context.Context) (bool, error) {
+		if err := d.DeleteMultilingualGlossary(ctx, id); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// GetDictionaryEntriesAsync returns a task that can be awaited to get the entries of a dictionary.
+func (d *Translator) GetDictionaryEntriesAsync(id string, source consts.SourceLang, target consts.TargetLang) tasker.TaskFunc[GlossaryEntries] {
+	return func(ctx context.Context) (GlossaryEntries, error) {
+		return d.GetDictionaryEntries(ctx, id, source, target)
+	}
+}
+
+// ReplaceDictionaryAsync returns a task that can be awaited to replace a dictionary, see ReplaceDictionary.
+func (d *Translator) ReplaceDictionaryAsync(id string, dictionary GlossaryDictionary) tasker.TaskFunc[types.GlossaryDictionaryInfo] {
+	return func(ctx context.Context) (types.GlossaryDictionaryInfo, error) {
+		return d.ReplaceDictionary(ctx, id, dictionary)
+	}
+}
+
+// DeleteDictionaryAsync returns a task that can be awaited to delete a dictionary.
+func (d *Translator) DeleteDictionaryAsync(id string, source consts.SourceLang, target consts.TargetLang) tasker.TaskFunc[bool] {
+	return func(ctx context.Context) (bool, error) {
+		if err := d.DeleteDictionary(ctx, id, source, target); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// SyncGlossaryAsync returns a task that can be awaited to reconcile a glossary, see SyncGlossary.
+func (d *Translator) SyncGlossaryAsync(
+	name string,
+	source consts.SourceLang,
+	target consts.TargetLang,
+	entries GlossaryEntries,
+) tasker.TaskFunc[types.GlossarySync] {
+	return func(ctx context.Context) (types.GlossarySync, error) {
+		return d.SyncGlossary(ctx, name, source, target, entries)
+	}
+}
+
+// FindGlossaryByNameAsync returns a task that can be awaited to find a glossary by name, see FindGlossaryByName.
+func (d *Translator) FindGlossaryByNameAsync(name string) tasker.TaskFunc[types.Glossary] {
+	return func(ctx context.Context) (types.Glossary, error) {
+		return d.FindGlossaryByName(ctx, name)
+	}
+}
+
+// WaitUntilGlossaryReadyAsync returns a task that can be awaited until a glossary is ready, see WaitUntilGlossaryReady.
+func (d *Translator) WaitUntilGlossaryReadyAsync(id string, opts ...func(*types.GlossaryWaitOptions) error) tasker.TaskFunc[types.Glossary] {
+	return func(ctx context.Context) (types.Glossary, error) {
+		return d.WaitUntilGlossaryReady(ctx, id, opts...)
+	}
+}
@@ -0,0 +1,301 @@
+package deepl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// defaultBatchConcurrency is the number of concurrent requests of a batch unless configured otherwise.
+const defaultBatchConcurrency = 4
+
+// batchJob is the translation of one input into one target language.
+// Inputs with a Path are opened when they are uploaded, the content of Reader inputs is
+// read once and shared by the jobs of all target languages until they are uploaded.
+type batchJob struct {
+	content []byte
+	options types.DocumentTranslateOptions
+	result  *types.DocumentResult
+}
+
+// TranslateDocuments translates every input into every target language.
+// Uploads, status checks and downloads run with bounded concurrency and the status
+// of all pending documents is polled in a single shared loop.
+// Failures are reported per document in the results and do not abort the batch;
+// the returned error is only set for invalid arguments or if ctx is done.
+func (d *Translator) TranslateDocuments(
+	ctx context.Context,
+	inputs []types.DocumentInput,
+	s consts.SourceLang,
+	targets []consts.TargetLang,
+	opts ...func(*types.BatchTranslateOptions) error,
+) (_ []types.DocumentResult, err error) {
+	ctx, end := d.observe(ctx, consts.OperationTranslateDocuments)
+	defer end(&err)
+	options := types.BatchTranslateOptions{Concurrency: defaultBatchConcurrency}
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return nil, err
+		}
+	}
+	if options.Output == nil && options.OutputDir == "" {
+		return nil, fmt.Errorf("%w: either an output directory or an output function is required", ErrInvalidOption)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%w: at least one target language is required", ErrInvalidOption)
+	}
+	documentOptions, err := documentTranslateOptions(nil, options.DocumentOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]types.DocumentResult, 0, len(inputs)*len(targets))
+	for _, input := range inputs {
+		for _, target := range targets {
+			results = append(results, types.DocumentResult{Input: input, TargetLang: target})
+		}
+	}
+	jobs := make([]*batchJob, 0, len(results))
+	for i, input := range inputs {
+		if input.FileName == "" && input.Path != "" {
+			input.FileName = filepath.Base(input.Path)
+		}
+		var content []byte
+		var err error
+		if input.Reader != nil {
+			content, err = io.ReadAll(input.Reader)
+		} else if input.Path == "" {
+			err = errors.New("document input needs a reader or a path")
+		}
+		for j := range targets {
+			result := &results[i*len(targets)+j]
+			result.Input = input
+			if err != nil {
+				result.Err = err
+				continue
+			}
+			jobOptions := documentOptions
+			jobOptions.FileName = input.FileName
+			if jobOptions.FileName == "" {
+				jobOptions.FileName = uuid.New().String()
+			}
+			jobs = append(jobs, &batchJob{content: content, options: jobOptions, result: result})
+		}
+	}
+
+	if options.Output == nil {
+		jobs = failOutputConflicts(jobs, options)
+	}
+
+	runBounded(jobs, options.Concurrency, func(job *batchJob) {
+		job.result.Document, job.result.Err = d.uploadBatchJob(ctx, s, job)
+	})
+	pending := make([]*batchJob, 0, len(jobs))
+	for _, job := range jobs {
+		if job.result.Err == nil {
+			pending = append(pending, job)
+		}
+	}
+	err = d.pollDocuments(ctx, pending, documentOptions, options)
+	return results, err
+}
+
+// pollDocuments checks the status of all pending jobs in rounds and downloads finished documents,
+// until no job is pending anymore.
+func (d *Translator) pollDocuments(
+	ctx context.Context,
+	pending []*batchJob,
+	documentOptions types.DocumentTranslateOptions,
+	options types.BatchTranslateOptions,
+) error {
+	strategy := documentOptions.PollStrategy
+	if strategy == nil {
+		strategy = d.options.PollStrategy
+	}
+	if strategy == nil {
+		strategy = NewServerHintPollStrategy(time.Second, time.Minute)
+	}
+	maxWait := documentOptions.MaxPollWait
+	if maxWait <= 0 {
+		maxWait = d.options.MaxPollWait
+	}
+	var deadline time.Time
+	if maxWait > 0 {
+		deadline = time.Now().Add(maxWait)
+	}
+	for round := 1; len(pending) > 0; round++ {
+		runBounded(pending, options.Concurrency, func(job *batchJob) {
+			d.checkBatchJob(ctx, job, options)
+		})
+		var interval time.Duration
+		remaining := pending[:0]
+		for _, job := range pending {
+			if job.result.Err != nil || job.result.Status.Done() {
+				continue
+			}
+			remaining = append(remaining, job)
+			next := positiveInterval(strategy.NextInterval(round, job.result.Status))
+			if interval == 0 || next < interval {
+				interval = next
+			}
+		}
+		pending = remaining
+		if len(pending) == 0 {
+			break
+		}
+		if !deadline.IsZero() {
+			left := time.Until(deadline)
+			if left <= 0 {
+				failBatchJobs(pending, ErrPollTimeout)
+				return nil
+			}
+			if interval > left {
+				interval = left
+			}
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			err := contextError(ctx, ctx.Err())
+			failBatchJobs(pending, err)
+			return err
+		case <-timer.C:
+		}
+	}
+	return contextError(ctx, nil)
+}
+
+// checkBatchJob updates the status of a job and downloads the document once it is done.
+func (d *Translator) checkBatchJob(ctx context.Context, job *batchJob, options types.BatchTranslateOptions) {
+	status, err := d.GetDocumentStatus(ctx, job.result.Document)
+	if err != nil {
+		job.result.Err = err
+		return
+	}
+	job.result.Status = status
+	notifyDocumentStatus(job.options, job.result.Document, status)
+	if !status.Ok() {
+		job.result.Err = &DocumentTranslationError{DocumentID: job.result.Document.DocumentID, Message: status.ErrorMessage}
+		return
+	}
+	if !status.Done() {
+		return
+	}
+	d.recordBilledCharacters(ctx, status)
+	w, path, err := openBatchOutput(job, options)
+	if err != nil {
+		job.result.Err = err
+		return
+	}
+	job.result.OutputPath = path
+	err = d.downloadDocument(ctx, job.result.Document, w, job.options)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil && path != "" {
+		// do not leave a partially written document behind
+		os.Remove(path)
+		job.result.OutputPath = ""
+	}
+	job.result.Err = err
+}
+
+// failOutputConflicts fails every job whose output path is already used by an earlier job,
+// e.g. inputs with the same file name from different directories, and returns the remaining jobs.
+func failOutputConflicts(jobs []*batchJob, options types.BatchTranslateOptions) []*batchJob {
+	used := map[string]types.DocumentInput{}
+	remaining := jobs[:0]
+	for _, job := range jobs {
+		path := batchOutputPath(job, options)
+		if other, ok := used[path]; ok {
+			job.result.Err = fmt.Errorf("%w: %s is written for %s already", ErrOutputConflict, path, inputName(other))
+			continue
+		}
+		used[path] = job.result.Input
+		remaining = append(remaining, job)
+	}
+	return remaining
+}
+
+// inputName returns the path of an input, or its file name for readers.
+func inputName(input types.DocumentInput) string {
+	if input.Path != "" {
+		return input.Path
+	}
+	return input.FileName
+}
+
+// openBatchOutput opens the writer for the translated document of a job.
+func openBatchOutput(job *batchJob, options types.BatchTranslateOptions) (io.WriteCloser, string, error) {
+	if options.Output != nil {
+		w, err := options.Output(job.result.Input, job.result.TargetLang)
+		return w, "", err
+	}
+	path := batchOutputPath(job, options)
+	f, err := os.Create(path)
+	return f, path, err
+}
+
+// batchOutputPath returns the path in the output directory the translated document of a job is written to.
+func batchOutputPath(job *batchJob, options types.BatchTranslateOptions) string {
+	name := job.options.FileName
+	ext := filepath.Ext(name)
+	if job.options.OutputFormat != "" {
+		ext = "." + job.options.OutputFormat
+	}
+	return filepath.Join(options.OutputDir, fmt.Sprintf("%s_%s%s", strings.TrimSuffix(name, filepath.Ext(name)), job.result.TargetLang, ext))
+}
+
+// uploadBatchJob uploads the input of a job, opening Path inputs only now so that not all
+// documents of a batch are open or in memory at the same time.
+func (d *Translator) uploadBatchJob(ctx context.Context, s consts.SourceLang, job *batchJob) (types.DocumentHandle, error) {
+	if job.content != nil {
+		content := job.content
+		job.content = nil
+		return d.uploadDocument(ctx, s, job.result.TargetLang, bytes.NewReader(content), job.options)
+	}
+	f, err := os.Open(job.result.Input.Path)
+	if err != nil {
+		return types.DocumentHandle{}, err
+	}
+	defer f.Close()
+	return d.uploadDocument(ctx, s, job.result.TargetLang, f, job.options)
+}
+
+// failBatchJobs sets err as the result of all jobs.
+func failBatchJobs(jobs []*batchJob, err error) {
+	for _, job := range jobs {
+		job.result.Err = err
+	}
+}
+
+// runBounded calls fn for every job with at most limit calls running concurrently.
+func runBounded[T any](jobs []T, limit int, fn func(T)) {
+	if limit <= 0 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(job T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(job)
+		}(job)
+	}
+	wg.Wait()
+}
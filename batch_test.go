@@ -0,0 +1,206 @@
+package deepl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// batchServer translates uploaded documents by prefixing them with the target language.
+// Each document is done after its second status check.
+func batchServer(t *testing.T, maxInFlight *int32) http.HandlerFunc {
+	var mu sync.Mutex
+	var inFlight, count int32
+	documents := map[string]string{}
+	checks := map[string]int{}
+	return func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v2/document"), "/")
+		switch {
+		case len(parts) == 1:
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				t.Error(err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			content, _ := io.ReadAll(file)
+			count++
+			id := fmt.Sprint(count)
+			documents[id] = r.FormValue("target_lang") + ":" + string(content)
+			fmt.Fprintf(w, `{"document_id":%q,"document_key":"key"}`, id)
+		case len(parts) == 2:
+			checks[parts[1]]++
+			status := "translating"
+			if checks[parts[1]] >= 2 {
+				status = "done"
+			}
+			fmt.Fprintf(w, `{"document_id":%q,"status":%q}`, parts[1], status)
+		default:
+			w.Write([]byte(documents[parts[1]]))
+		}
+	}
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestTranslator_TranslateDocuments(t *testing.T) {
+	var maxInFlight int32
+	translator := makeLocalTranslator(t, batchServer(t, &maxInFlight), WithPollStrategy(NewFixedPollStrategy(time.Millisecond)))
+	dir := t.TempDir()
+	path := filepath.Join(dir, "beam.txt")
+	if err := os.WriteFile(path, []byte("beam"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	outputs := map[string]*bytes.Buffer{}
+	inputs := []types.DocumentInput{
+		{Reader: strings.NewReader("proton"), FileName: "proton.txt"},
+		{Path: path},
+		{Path: filepath.Join(dir, "missing.txt")},
+	}
+	for i := 0; i < 5; i++ {
+		inputs = append(inputs, types.DocumentInput{Reader: strings.NewReader(fmt.Sprint(i)), FileName: fmt.Sprintf("%d.txt", i)})
+	}
+	results, err := translator.TranslateDocuments(context.Background(), inputs, consts.SourceLangEnglish,
+		[]consts.TargetLang{consts.TargetLangGerman, consts.TargetLangFrench},
+		WithConcurrency(2),
+		WithOutput(func(input types.DocumentInput, target consts.TargetLang) (io.WriteCloser, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			buf := &bytes.Buffer{}
+			outputs[input.FileName+"/"+string(target)] = buf
+			return nopWriteCloser{buf}, nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(inputs)*2 {
+		t.Fatalf("got %d results", len(results))
+	}
+	for _, result := range results {
+		if result.Input.FileName == "missing.txt" {
+			if result.Err == nil {
+				t.Errorf("missing input did not fail")
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Errorf("%s to %s: %v", result.Input.FileName, result.TargetLang, result.Err)
+		}
+	}
+	if got := outputs["proton.txt/FR"].String(); got != "FR:proton" {
+		t.Errorf("got output %q", got)
+	}
+	if got := outputs["beam.txt/DE"].String(); got != "DE:beam" {
+		t.Errorf("got output %q", got)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("concurrency limit exceeded: %d requests in flight", maxInFlight)
+	}
+}
+
+func TestTranslator_TranslateDocumentsOutputDir(t *testing.T) {
+	var maxInFlight int32
+	translator := makeLocalTranslator(t, batchServer(t, &maxInFlight), WithPollStrategy(NewFixedPollStrategy(time.Millisecond)))
+	dir := t.TempDir()
+	results, err := translator.TranslateDocuments(context.Background(),
+		[]types.DocumentInput{{Reader: strings.NewReader("proton"), FileName: "proton.txt"}},
+		consts.SourceLangEnglish, []consts.TargetLang{consts.TargetLangGerman},
+		WithOutputDir(dir),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Err != nil {
+		t.Fatal(results[0].Err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "proton_DE.txt"))
+	if err != nil || string(content) != "DE:proton" || results[0].OutputPath != filepath.Join(dir, "proton_DE.txt") {
+		t.Errorf("got %q, %v", content, err)
+	}
+}
+
+func TestTranslator_TranslateDocumentsOutputConflict(t *testing.T) {
+	var maxInFlight int32
+	translator := makeLocalTranslator(t, batchServer(t, &maxInFlight), WithPollStrategy(NewFixedPollStrategy(time.Millisecond)))
+	dir := t.TempDir()
+	var inputs []types.DocumentInput
+	for _, sub := range []string{"a", "b"} {
+		path := filepath.Join(dir, sub, "proton.txt")
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		if err := os.WriteFile(path, []byte(sub), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, types.DocumentInput{Path: path})
+	}
+	out := t.TempDir()
+	results, err := translator.TranslateDocuments(context.Background(), inputs,
+		consts.SourceLangEnglish, []consts.TargetLang{consts.TargetLangGerman},
+		WithOutputDir(out),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Err != nil || !errors.Is(results[1].Err, ErrOutputConflict) {
+		t.Fatalf("got errors %v and %v, want the second input to conflict", results[0].Err, results[1].Err)
+	}
+	content, err := os.ReadFile(filepath.Join(out, "proton_DE.txt"))
+	if err != nil || string(content) != "DE:a" {
+		t.Errorf("got %q, %v, want the first input", content, err)
+	}
+}
+
+func TestTranslator_TranslateDocumentsRemovesFailedOutput(t *testing.T) {
+	var maxInFlight int32
+	server := batchServer(t, &maxInFlight)
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/result") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		server(w, r)
+	}, WithPollStrategy(NewFixedPollStrategy(time.Millisecond)), WithRetryBackoff(time.Millisecond, time.Millisecond, 1, 0))
+	dir := t.TempDir()
+	results, err := translator.TranslateDocuments(context.Background(),
+		[]types.DocumentInput{{Reader: strings.NewReader("proton"), FileName: "proton.txt"}},
+		consts.SourceLangEnglish, []consts.TargetLang{consts.TargetLangGerman},
+		WithOutputDir(dir),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Err == nil || results[0].OutputPath != "" {
+		t.Errorf("got %+v, want a failed download without output", results[0])
+	}
+	if _, err := os.Stat(filepath.Join(dir, "proton_DE.txt")); !os.IsNotExist(err) {
+		t.Errorf("got %v, want the partial output to be removed", err)
+	}
+}
@@ -0,0 +1,119 @@
+package deepl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sort"
+
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// cacheKeyVersion is part of every cache key and must be changed if the cached format changes.
+const cacheKeyVersion = "deepl/v1"
+
+// cacheMiss is a distinct text that is not cached, with the indexes it occurs at.
+type cacheMiss struct {
+	key     string
+	indexes []int
+}
+
+// translateCached translates text like translateChunked but serves texts from the cache of the
+// Translator and only sends texts that are not cached, each distinct text once.
+func (d *Translator) translateCached(ctx context.Context, text []string, params url.Values) ([]types.Translation, error) {
+	translations := make([]types.Translation, len(text))
+	cached := map[string]types.Translation{}
+	misses := map[string]*cacheMiss{}
+	var missing []string
+	for i, t := range text {
+		if translation, ok := cached[t]; ok {
+			translations[i] = translation
+			continue
+		}
+		if miss, ok := misses[t]; ok {
+			miss.indexes = append(miss.indexes, i)
+			continue
+		}
+		key := cacheKey(t, params)
+		if data, ok := d.options.Cache.Get(key); ok && json.Unmarshal(data, &translations[i]) == nil {
+			cached[t] = translations[i]
+			continue
+		}
+		misses[t] = &cacheMiss{key: key, indexes: []int{i}}
+		missing = append(missing, t)
+	}
+	if len(missing) == 0 {
+		return translations, nil
+	}
+
+	result, err := d.translateChunked(ctx, missing, params)
+	var partial *PartialTranslationError
+	if err != nil && !errors.As(err, &partial) {
+		return nil, err
+	}
+	failed := make([]error, len(missing))
+	if partial != nil {
+		for _, chunk := range partial.Failed {
+			for j := chunk.Start; j < chunk.End; j++ {
+				failed[j] = chunk.Err
+			}
+		}
+	}
+	for j, t := range missing {
+		if failed[j] != nil {
+			continue
+		}
+		miss := misses[t]
+		for _, i := range miss.indexes {
+			translations[i] = result[j]
+		}
+		if data, err := json.Marshal(result[j]); err == nil {
+			d.options.Cache.Set(miss.key, data)
+		}
+	}
+	if partial != nil {
+		return translations, &PartialTranslationError{Failed: failedRanges(missing, misses, failed)}
+	}
+	return translations, nil
+}
+
+// failedRanges maps the failed texts of a cached translation back to ranges of the original texts.
+func failedRanges(missing []string, misses map[string]*cacheMiss, failed []error) []*ChunkError {
+	errs := map[int]error{}
+	var indexes []int
+	for j, t := range missing {
+		if failed[j] == nil {
+			continue
+		}
+		for _, i := range misses[t].indexes {
+			errs[i] = failed[j]
+			indexes = append(indexes, i)
+		}
+	}
+	sort.Ints(indexes)
+	var ranges []*ChunkError
+	for _, i := range indexes {
+		if last := len(ranges) - 1; last >= 0 && ranges[last].End == i && ranges[last].Err == errs[i] {
+			ranges[last].End++
+			continue
+		}
+		ranges = append(ranges, &ChunkError{Start: i, End: i + 1, Err: errs[i]})
+	}
+	return ranges
+}
+
+// cacheKey returns the cache key of text translated with the given parameters,
+// which include the languages and all translate options.
+func cacheKey(text string, params url.Values) string {
+	h := sha256.New()
+	h.Write([]byte(cacheKeyVersion))
+	h.Write([]byte{0})
+	// Encode sorts by key, so equal options always result in the same key
+	h.Write([]byte(params.Encode()))
+	h.Write([]byte{0})
+	h.Write([]byte(text))
+	return hex.EncodeToString(h.Sum(nil))
+}
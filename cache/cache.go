@@ -0,0 +1,52 @@
+// Package cache provides backends for caching translation results of a Translator,
+// see deepl.WithCache.
+//
+// Both backends store opaque values by key, expire entries after a TTL and count
+// hits and misses. They are safe for concurrent use.
+package cache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats are the statistics of a cache since it was created.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Sets      uint64
+	Evictions uint64
+	// number of entries currently stored, including expired entries not removed yet
+	Entries int
+}
+
+// counters are the statistics shared by all backends.
+type counters struct {
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	sets      atomic.Uint64
+	evictions atomic.Uint64
+}
+
+func (c *counters) stats(entries int) Stats {
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Sets:      c.sets.Load(),
+		Evictions: c.evictions.Load(),
+		Entries:   entries,
+	}
+}
+
+// expiry returns the time an entry set at now expires, the zero time if ttl is not positive.
+func expiry(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
+
+// expired reports whether an entry with the given expiry is expired at now.
+func expired(expires, now time.Time) bool {
+	return !expires.IsZero() && !now.Before(expires)
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+// clock is a manually advanced time source.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func TestLRU_Eviction(t *testing.T) {
+	c := NewLRU(2, 0)
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+	c.Get("a")
+	c.Set("c", []byte("3"))
+	if _, ok := c.Get("b"); ok {
+		t.Error("least recently used entry was not evicted")
+	}
+	if v, ok := c.Get("a"); !ok || string(v) != "1" {
+		t.Errorf("got %q, %v, want 1", v, ok)
+	}
+	want := Stats{Hits: 2, Misses: 1, Sets: 3, Evictions: 1, Entries: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("got stats %+v, want %+v", got, want)
+	}
+}
+
+func TestLRU_TTL(t *testing.T) {
+	clk := &clock{t: time.Now()}
+	c := NewLRU(10, time.Minute)
+	c.now = clk.now
+	c.Set("a", []byte("1"))
+	clk.t = clk.t.Add(59 * time.Second)
+	if _, ok := c.Get("a"); !ok {
+		t.Error("entry expired too early")
+	}
+	clk.t = clk.t.Add(time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Error("entry did not expire")
+	}
+}
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	clk := &clock{t: time.Now()}
+	c, err := NewFile(dir, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.now = clk.now
+	c.Set("a", []byte("1"))
+	c.Set("b/../b", []byte("2"))
+	if v, ok := c.Get("b/../b"); !ok || string(v) != "2" {
+		t.Errorf("got %q, %v, want 2", v, ok)
+	}
+
+	// entries are shared with other caches of the same directory
+	other, _ := NewFile(dir, time.Minute)
+	if v, ok := other.Get("a"); !ok || string(v) != "1" {
+		t.Errorf("got %q, %v, want 1", v, ok)
+	}
+
+	clk.t = clk.t.Add(time.Minute)
+	if err := c.Prune(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Error("entry did not expire")
+	}
+	want := Stats{Hits: 1, Misses: 1, Sets: 2, Evictions: 2, Entries: 0}
+	if got := c.Stats(); got != want {
+		t.Errorf("got stats %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// File is an on-disk cache storing every entry in its own file of a directory,
+// so cached translations survive restarts and can be shared between processes.
+type File struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+	counters
+}
+
+// fileExt is the extension of entry files, other files in the directory are ignored.
+const fileExt = ".cache"
+
+// NewFile returns a File cache in dir, creating the directory if needed.
+// Entries expire after ttl, or never if ttl is zero.
+func NewFile(dir string, ttl time.Duration) (*File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &File{dir: dir, ttl: ttl, now: time.Now}, nil
+}
+
+// Get returns the value stored for key and whether it was found and not expired.
+// Unreadable entries are treated as missing.
+func (c *File) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) < 8 {
+		c.misses.Add(1)
+		return nil, false
+	}
+	var expires time.Time
+	if nanos := int64(binary.BigEndian.Uint64(data)); nanos != 0 {
+		expires = time.Unix(0, nanos)
+	}
+	if expired(expires, c.now()) {
+		if os.Remove(path) == nil {
+			c.evictions.Add(1)
+		}
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	return data[8:], true
+}
+
+// Set stores value for key. Write errors are ignored, a failed entry is simply not cached.
+func (c *File) Set(key string, value []byte) {
+	c.sets.Add(1)
+	data := make([]byte, 8, 8+len(value))
+	if expires := expiry(c.now(), c.ttl); !expires.IsZero() {
+		binary.BigEndian.PutUint64(data, uint64(expires.UnixNano()))
+	}
+	data = append(data, value...)
+	// write to a temporary file first so readers never see partial entries
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Delete removes the entry for key.
+func (c *File) Delete(key string) {
+	os.Remove(c.path(key))
+}
+
+// Stats returns the statistics of the cache. Entries counts the entry files in the directory.
+func (c *File) Stats() Stats {
+	entries, _ := os.ReadDir(c.dir)
+	n := 0
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), fileExt) {
+			n++
+		}
+	}
+	return c.stats(n)
+}
+
+// Prune removes all expired entries.
+func (c *File) Prune() error {
+	now := c.now()
+	return filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, fileExt) {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		var nanos int64
+		err = binary.Read(f, binary.BigEndian, &nanos)
+		f.Close()
+		if err == nil && nanos != 0 && expired(time.Unix(0, nanos), now) {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			c.evictions.Add(1)
+		}
+		return nil
+	})
+}
+
+// path returns the file of key, hashed so any key is a valid file name.
+func (c *File) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+fileExt)
+}
@@ -0,0 +1,69 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hsedr/deepl-golang/cache"
+	"github.com/hsedr/deepl-golang/consts"
+)
+
+func TestTranslator_TranslateTextCache(t *testing.T) {
+	var requests int32
+	c := cache.NewLRU(100, 0)
+	translator := makeLocalTranslator(t, echoServer(t, &requests), WithCache(c))
+	ctx := context.Background()
+
+	texts := []string{"hello", "world", "hello"}
+	for i := 0; i < 2; i++ {
+		translations, err := translator.TranslateText(ctx, texts, consts.SourceLangEnglish, consts.TargetLangGerman)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j, translation := range translations {
+			if translation.Text != strings.ToUpper(texts[j]) {
+				t.Errorf("got translation %q for %q", translation.Text, texts[j])
+			}
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1", requests)
+	}
+	if stats := c.Stats(); stats.Hits != 2 || stats.Sets != 2 {
+		t.Errorf("got stats %+v, want 2 hits and 2 sets", stats)
+	}
+
+	// other options must not be served from the cache
+	if _, err := translator.TranslateText(ctx, texts[:1], consts.SourceLangEnglish, consts.TargetLangGerman, WithFormality(consts.More)); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, want 2", requests)
+	}
+}
+
+func TestTranslator_TranslateTextCachePartial(t *testing.T) {
+	var requests int32
+	c := cache.NewLRU(100, 0)
+	translator := makeLocalTranslator(t, echoServer(t, &requests), WithCache(c), WithTextChunking(1, 1<<20))
+	texts := []string{"cached", "fail", "new", "fail"}
+	c.Set(cacheKey("cached", url.Values{"source_lang": {"EN"}, "target_lang": {"DE"}}), []byte(`{"text":"CACHED"}`))
+
+	translations, err := translator.TranslateText(context.Background(), texts, consts.SourceLangEnglish, consts.TargetLangGerman)
+	var partial *PartialTranslationError
+	if !errors.As(err, &partial) {
+		t.Fatalf("got error %v, want PartialTranslationError", err)
+	}
+	if len(partial.Failed) != 2 || partial.Failed[0].Start != 1 || partial.Failed[1].Start != 3 {
+		t.Errorf("got failed ranges %v, want texts 1 and 3", partial.Failed)
+	}
+	if translations[0].Text != "CACHED" || translations[2].Text != "NEW" {
+		t.Errorf("got translations %v", translations)
+	}
+	if _, ok := c.Get(cacheKey("fail", url.Values{"source_lang": {"EN"}, "target_lang": {"DE"}})); ok {
+		t.Error("failed translation must not be cached")
+	}
+}
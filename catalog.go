@@ -0,0 +1,230 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// ErrUnsupportedLanguage is returned when a language, a language pair or formality is not supported by DeepL.
+var ErrUnsupportedLanguage = errors.New("deepl: unsupported language")
+
+// LanguageCatalog holds the languages supported by DeepL. A new catalog knows the languages of
+// the consts package and can be refreshed from the API, so new languages need no library release.
+// It is safe for concurrent use.
+type LanguageCatalog struct {
+	mu       sync.RWMutex
+	source   map[string]types.SupportedLanguage
+	target   map[string]types.SupportedLanguage
+	glossary map[[2]string]bool
+	updated  time.Time
+}
+
+// NewLanguageCatalog returns a catalog of the languages known to this version of the library.
+func NewLanguageCatalog() *LanguageCatalog {
+	c := &LanguageCatalog{
+		source:   map[string]types.SupportedLanguage{},
+		target:   map[string]types.SupportedLanguage{},
+		glossary: map[[2]string]bool{},
+	}
+	for _, lang := range consts.SourceLanguages {
+		c.source[string(lang)] = types.SupportedLanguage{Language: string(lang)}
+	}
+	for _, lang := range consts.TargetLanguages {
+		c.target[string(lang)] = types.SupportedLanguage{Language: string(lang)}
+	}
+	for _, lang := range consts.FormalityTargetLanguages {
+		c.target[string(lang)] = types.SupportedLanguage{Language: string(lang), SupportsFormality: true}
+	}
+	for _, source := range consts.GlossaryLanguages {
+		for _, target := range consts.GlossaryLanguages {
+			if source != target {
+				c.glossary[[2]string{string(source), string(target)}] = true
+			}
+		}
+	}
+	return c
+}
+
+// Refresh replaces the languages of the catalog with the languages currently supported by DeepL.
+func (c *LanguageCatalog) Refresh(ctx context.Context, d *Translator) error {
+	source, err := d.GetLanguages(ctx, "source")
+	if err != nil {
+		return err
+	}
+	target, err := d.GetLanguages(ctx, "target")
+	if err != nil {
+		return err
+	}
+	pairs, err := d.GetGlossaryLanguages(ctx)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.source = languageMap(source)
+	c.target = languageMap(target)
+	c.glossary = map[[2]string]bool{}
+	for _, pair := range pairs.SupportedLanguages {
+		c.glossary[[2]string{strings.ToUpper(pair.SourceLang), strings.ToUpper(pair.TargetLang)}] = true
+	}
+	c.updated = time.Now()
+	return nil
+}
+
+// Updated returns when the catalog was last refreshed, the zero time if it only knows the static languages.
+func (c *LanguageCatalog) Updated() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.updated
+}
+
+// SourceLanguages returns the supported source languages sorted by code.
+func (c *LanguageCatalog) SourceLanguages() []types.SupportedLanguage {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return sortedLanguages(c.source)
+}
+
+// TargetLanguages returns the supported target languages sorted by code.
+func (c *LanguageCatalog) TargetLanguages() []types.SupportedLanguage {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return sortedLanguages(c.target)
+}
+
+// ValidateTranslation checks that source and target are supported and that target supports the formality.
+// consts.SourceLangAuto is always valid, as are the prefer_more and prefer_less formalities,
+// which fall back to the default for languages without formality.
+func (c *LanguageCatalog) ValidateTranslation(source consts.SourceLang, target consts.TargetLang, formality consts.Formality) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if source != consts.SourceLangAuto {
+		if _, ok := c.source[strings.ToUpper(string(source))]; !ok {
+			return fmt.Errorf("%w: source language %q", ErrUnsupportedLanguage, source)
+		}
+	}
+	lang, ok := c.target[strings.ToUpper(string(target))]
+	if !ok {
+		if variants := c.variants(string(target)); len(variants) > 0 {
+			return fmt.Errorf("%w: target language %q, use one of %s", ErrUnsupportedLanguage, target, strings.Join(variants, ", "))
+		}
+		return fmt.Errorf("%w: target language %q", ErrUnsupportedLanguage, target)
+	}
+	if (formality == consts.More || formality == consts.Less) && !lang.SupportsFormality {
+		return fmt.Errorf("%w: target language %q does not support formality %q", ErrUnsupportedLanguage, target, formality)
+	}
+	return nil
+}
+
+// ValidateGlossary checks that glossaries can be created for the language pair.
+func (c *LanguageCatalog) ValidateGlossary(source consts.SourceLang, target consts.TargetLang) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	base := strings.ToUpper(baseLanguage(string(target)))
+	if !c.glossary[[2]string{strings.ToUpper(string(source)), base}] {
+		return fmt.Errorf("%w: glossaries from %q to %q", ErrUnsupportedLanguage, source, target)
+	}
+	return nil
+}
+
+// variants returns the target languages that are variants of lang, e.g. EN-GB and EN-US for EN.
+func (c *LanguageCatalog) variants(lang string) []string {
+	var variants []string
+	prefix := strings.ToUpper(lang) + "-"
+	for code := range c.target {
+		if strings.HasPrefix(code, prefix) {
+			variants = append(variants, code)
+		}
+	}
+	sort.Strings(variants)
+	return variants
+}
+
+func languageMap(languages []types.SupportedLanguage) map[string]types.SupportedLanguage {
+	m := make(map[string]types.SupportedLanguage, len(languages))
+	for _, lang := range languages {
+		m[strings.ToUpper(lang.Language)] = lang
+	}
+	return m
+}
+
+func sortedLanguages(m map[string]types.SupportedLanguage) []types.SupportedLanguage {
+	languages := make([]types.SupportedLanguage, 0, len(m))
+	for _, lang := range m {
+		languages = append(languages, lang)
+	}
+	sort.Slice(languages, func(i, j int) bool { return languages[i].Language < languages[j].Language })
+	return languages
+}
+
+// WithLanguageValidation validates languages and formality of translations and glossaries against the
+// language catalog of the Translator before they are sent. The catalog is refreshed from DeepL on first use
+// and then every refreshInterval, or never again if it is zero.
+func WithLanguageValidation(refreshInterval time.Duration) func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		if refreshInterval < 0 {
+			return fmt.Errorf("%w: language refresh interval must not be negative", ErrInvalidOption)
+		}
+		options.ValidateLanguages = true
+		options.LanguageRefreshInterval = refreshInterval
+		return nil
+	}
+}
+
+// languageRetryDelay is the time to wait after a failed refresh of the language catalog before trying again.
+const languageRetryDelay = time.Minute
+
+// GetLanguageCatalog returns the language catalog of the Translator, refreshed from DeepL
+// if it was never refreshed or is older than the refresh interval of WithLanguageValidation.
+// After a failed refresh, the catalog is not refreshed again for a minute and the error of the
+// failed refresh is returned along with the languages known so far. While another call refreshes
+// the catalog, the languages known so far are returned without waiting.
+func (d *Translator) GetLanguageCatalog(ctx context.Context) (*LanguageCatalog, error) {
+	d.languagesMu.Lock()
+	updated := d.languages.Updated()
+	interval := d.options.LanguageRefreshInterval
+	stale := updated.IsZero() || (interval > 0 && time.Since(updated) >= interval)
+	switch {
+	case !stale || d.languagesRefreshing:
+		d.languagesMu.Unlock()
+		return d.languages, nil
+	case !d.languagesFailed.IsZero() && time.Since(d.languagesFailed) < languageRetryDelay:
+		err := d.languagesErr
+		d.languagesMu.Unlock()
+		return d.languages, err
+	}
+	d.languagesRefreshing = true
+	d.languagesMu.Unlock()
+
+	// refresh without holding the lock, so concurrent calls are not serialized behind it
+	err := d.languages.Refresh(ctx, d)
+
+	d.languagesMu.Lock()
+	defer d.languagesMu.Unlock()
+	d.languagesRefreshing = false
+	if err != nil {
+		d.languagesFailed, d.languagesErr = time.Now(), err
+		return d.languages, err
+	}
+	d.languagesFailed, d.languagesErr = time.Time{}, nil
+	return d.languages, nil
+}
+
+// languageCatalog returns the catalog used for validation, nil if validation is disabled.
+// If refreshing fails, the languages known so far are used and the refresh is only retried after
+// languageRetryDelay, so an unreachable languages endpoint does not block or slow down translations.
+func (d *Translator) languageCatalog(ctx context.Context) *LanguageCatalog {
+	if !d.options.ValidateLanguages {
+		return nil
+	}
+	catalog, _ := d.GetLanguageCatalog(ctx)
+	return catalog
+}
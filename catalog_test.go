@@ -0,0 +1,109 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/deepltest"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+func TestLanguageCatalog_ValidateTranslation(t *testing.T) {
+	catalog := NewLanguageCatalog()
+	tests := []struct {
+		source    consts.SourceLang
+		target    consts.TargetLang
+		formality consts.Formality
+		valid     bool
+	}{
+		{consts.SourceLangEnglish, consts.TargetLangGerman, consts.More, true},
+		{consts.SourceLangAuto, consts.TargetLangGerman, "", true},
+		{consts.SourceLangGerman, consts.TargetLangEnglishGB, consts.PreferLess, true},
+		{consts.SourceLangGerman, consts.TargetLangEnglishGB, consts.Less, false},
+		{consts.SourceLangGerman, "EN", "", false},
+		{"XX", consts.TargetLangGerman, "", false},
+	}
+	for _, test := range tests {
+		err := catalog.ValidateTranslation(test.source, test.target, test.formality)
+		if (err == nil) != test.valid {
+			t.Errorf("ValidateTranslation(%q, %q, %q) = %v, want valid %v", test.source, test.target, test.formality, err, test.valid)
+		}
+		if err != nil && !errors.Is(err, ErrUnsupportedLanguage) {
+			t.Errorf("got %v, want ErrUnsupportedLanguage", err)
+		}
+	}
+	if err := catalog.ValidateTranslation(consts.SourceLangGerman, "EN", ""); err == nil || !strings.Contains(err.Error(), "EN-GB, EN-US") {
+		t.Errorf("got %v, want the variants of EN suggested", err)
+	}
+	if err := catalog.ValidateGlossary(consts.SourceLangEnglish, consts.TargetLangEnglishUS); err == nil {
+		t.Error("glossaries within one language must be invalid")
+	}
+}
+
+func TestTranslator_LanguageValidation(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+	var languageRequests int
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/languages" {
+			languageRequests++
+		}
+		if r.URL.Path == "/v2/languages" && r.URL.Query().Get("type") == "target" {
+			// a language unknown to the library
+			w.Write([]byte(`[{"language": "XY", "name": "New", "supports_formality": true}]`))
+			return
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}, WithLanguageValidation(0))
+	ctx := context.Background()
+
+	if _, err := translator.TranslateText(ctx, []string{"proton beam"}, consts.SourceLangEnglish, consts.TargetLangGerman); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("got %v, want DE to be unsupported after the refresh", err)
+	}
+	if _, err := translator.TranslateText(ctx, []string{"proton beam"}, consts.SourceLangEnglish, "XY", WithFormality(consts.More)); errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("got %v, want XY to be valid after the refresh", err)
+	}
+	if languageRequests != 2 {
+		t.Errorf("got %d language requests, want the catalog to be refreshed once", languageRequests)
+	}
+	catalog, err := translator.GetLanguageCatalog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := catalog.TargetLanguages(); len(got) != 1 || got[0] != (types.SupportedLanguage{Language: "XY", Name: "New", SupportsFormality: true}) {
+		t.Errorf("got target languages %v", got)
+	}
+}
+
+func TestTranslator_LanguageRefreshFailure(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+	var languageRequests int32
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/languages" {
+			atomic.AddInt32(&languageRequests, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}, WithLanguageValidation(0), WithRetryBackoff(time.Millisecond, time.Millisecond, 1, 0))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := translator.TranslateText(ctx, []string{"proton beam"}, consts.SourceLangEnglish, consts.TargetLangGerman); err != nil {
+			t.Fatalf("translation %d: %v", i, err)
+		}
+	}
+	if got := atomic.LoadInt32(&languageRequests); got != 2 {
+		t.Errorf("got %d language requests, want a single failed refresh with one retry", got)
+	}
+	if _, err := translator.GetLanguageCatalog(ctx); err == nil {
+		t.Error("got no error, want the error of the failed refresh")
+	}
+}
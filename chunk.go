@@ -0,0 +1,116 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hsedr/deepl-golang/types"
+)
+
+const (
+	// defaultMaxTextsPerRequest is the maximum number of texts the API accepts per request.
+	defaultMaxTextsPerRequest = 50
+	// defaultMaxRequestBytes is the maximum request size the API accepts.
+	defaultMaxRequestBytes = 128 << 10
+)
+
+// textChunk is a slice of texts translated in a single request, starting at index start.
+type textChunk struct {
+	start int
+	texts []string
+}
+
+// translateChunked translates text in as many requests as needed to stay within the request limits
+// and reassembles the translations in the original order.
+// The characters are checked against the quota guard first.
+func (d *Translator) translateChunked(ctx context.Context, text []string, params url.Values) (translations []types.Translation, err error) {
+	characters := countCharacters(text)
+	if err := d.reserveCharacters(ctx, characters); err != nil {
+		return nil, contextError(ctx, err)
+	}
+	defer func() {
+		var partial *PartialTranslationError
+		switch {
+		case errors.As(err, &partial):
+			// characters of failed chunks were not translated
+			used := characters
+			for _, failed := range partial.Failed {
+				used -= countCharacters(text[failed.Start:failed.End])
+			}
+			d.settleCharacters(characters, used)
+		case err != nil:
+			d.settleCharacters(characters, 0)
+		default:
+			d.settleCharacters(characters, characters)
+		}
+	}()
+	maxTexts := d.options.MaxTextsPerRequest
+	if maxTexts <= 0 {
+		maxTexts = defaultMaxTextsPerRequest
+	}
+	maxBytes := d.options.MaxRequestBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxRequestBytes
+	}
+	chunks, err := splitTexts(text, len(params.Encode()), maxTexts, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(chunks) <= 1 {
+		return d.translateTexts(ctx, text, params)
+	}
+
+	translations = make([]types.Translation, len(text))
+	errs := make([]*ChunkError, len(chunks))
+	indexes := make([]int, len(chunks))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	runBounded(indexes, d.options.ChunkConcurrency, func(i int) {
+		chunk := chunks[i]
+		result, err := d.translateTexts(ctx, chunk.texts, params)
+		if err != nil {
+			errs[i] = &ChunkError{Start: chunk.start, End: chunk.start + len(chunk.texts), Err: err}
+			return
+		}
+		copy(translations[chunk.start:], result)
+	})
+
+	var failed []*ChunkError
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return translations, &PartialTranslationError{Failed: failed}
+	}
+	return translations, nil
+}
+
+// splitTexts splits texts into chunks of at most maxTexts texts whose form encoded size,
+// together with baseSize bytes of other parameters, does not exceed maxBytes.
+func splitTexts(texts []string, baseSize, maxTexts, maxBytes int) ([]textChunk, error) {
+	var chunks []textChunk
+	current := textChunk{}
+	size := baseSize
+	for i, text := range texts {
+		textSize := len("&text=") + len(url.QueryEscape(text))
+		if baseSize+textSize > maxBytes {
+			return nil, fmt.Errorf("%w: text %d has %d bytes encoded", ErrTextTooLarge, i, textSize)
+		}
+		if len(current.texts) == maxTexts || (len(current.texts) > 0 && size+textSize > maxBytes) {
+			chunks = append(chunks, current)
+			current = textChunk{start: i}
+			size = baseSize
+		}
+		current.texts = append(current.texts, text)
+		size += textSize
+	}
+	if len(current.texts) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks, nil
+}
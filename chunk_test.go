@@ -0,0 +1,114 @@
+package deepl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// echoServer translates texts to upper case and fails requests containing the text "fail".
+func echoServer(t *testing.T, requests *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var response types.Translations
+		for _, text := range r.PostForm["text"] {
+			if text == "fail" {
+				w.WriteHeader(456)
+				return
+			}
+			response.Translations = append(response.Translations, types.Translation{DetectedSourceLanguage: "EN", Text: strings.ToUpper(text)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func TestTranslator_TranslateTextChunking(t *testing.T) {
+	var requests int32
+	translator := makeLocalTranslator(t, echoServer(t, &requests), WithTextChunking(50, 1<<20), WithChunkConcurrency(3))
+	texts := make([]string, 120)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("text %d", i)
+	}
+	translations, err := translator.TranslateText(context.Background(), texts, consts.SourceLangEnglish, consts.TargetLangGerman)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Errorf("got %d requests, want 3", requests)
+	}
+	for i, translation := range translations {
+		if translation.Text != strings.ToUpper(texts[i]) {
+			t.Fatalf("translation %d out of order: %q", i, translation.Text)
+		}
+	}
+}
+
+func TestSplitTexts(t *testing.T) {
+	texts := []string{"aaaa", "bbbb", "cccc", "dddd", "eeee"}
+	// every text takes 10 bytes encoded
+	chunks, err := splitTexts(texts, 0, 50, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 3 || chunks[1].start != 2 || len(chunks[2].texts) != 1 {
+		t.Errorf("got chunks %+v", chunks)
+	}
+	if _, err := splitTexts([]string{strings.Repeat("a", 100)}, 0, 50, 25); !errors.Is(err, ErrTextTooLarge) {
+		t.Errorf("got %v, want ErrTextTooLarge", err)
+	}
+}
+
+func TestTranslator_TranslateTextPartialFailure(t *testing.T) {
+	var requests int32
+	translator := makeLocalTranslator(t, echoServer(t, &requests), WithTextChunking(2, 1<<20))
+	texts := []string{"a", "b", "fail", "c", "d"}
+	translations, err := translator.TranslateText(context.Background(), texts, consts.SourceLangEnglish, consts.TargetLangGerman)
+	var partial *PartialTranslationError
+	if !errors.As(err, &partial) {
+		t.Fatalf("got %v, want *PartialTranslationError", err)
+	}
+	if len(partial.Failed) != 1 || partial.Failed[0].Start != 2 || partial.Failed[0].End != 4 {
+		t.Errorf("got failed chunks %+v", partial.Failed)
+	}
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Errorf("chunk error is not a *QuotaExceededError: %v", err)
+	}
+	if translations[1].Text != "B" || translations[2].Text != "" || translations[4].Text != "D" {
+		t.Errorf("got translations %+v", translations)
+	}
+}
+
+func TestTranslator_TranslateTextPartialFailureQuota(t *testing.T) {
+	var requests int32
+	echo := echoServer(t, &requests)
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/usage" {
+			w.Write([]byte(`{"character_count": 0, "character_limit": 1000}`))
+			return
+		}
+		echo(w, r)
+	}, WithTextChunking(2, 1<<20), WithQuotaGuard(types.QuotaOptions{}))
+	texts := []string{"a", "b", "fail", "c", "d"}
+	if _, err := translator.TranslateText(context.Background(), texts, consts.SourceLangEnglish, consts.TargetLangGerman); err == nil {
+		t.Fatal("got no error, want a partial failure")
+	}
+	// the characters of the failed chunk "fail", "c" are released
+	if q := translator.quota; q.local != 3 || q.reserved != 0 {
+		t.Errorf("got %d used and %d reserved characters, want 3 and 0", q.local, q.reserved)
+	}
+}
@@ -2,11 +2,14 @@ package deepl
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/ybbus/httpretry"
+	"github.com/hsedr/deepl-golang/types"
 )
 
 type Transport struct {
@@ -15,6 +18,28 @@ type Transport struct {
 	TimeOut   time.Duration
 	Retries   int
 	Transport http.RoundTripper
+
+	// delays between retries and the timeout of a single attempt. If AttemptTimeout is set,
+	// TimeOut limits all attempts together, otherwise TimeOut limits every attempt on its own
+	// so the backoff and Retry-After delays between attempts are not cut short.
+	Backoff        types.RetryBackoff
+	AttemptTimeout time.Duration
+
+	// logs every attempt of a request, nil disables logging
+	Logger *slog.Logger
+	// logs auth keys, document keys and request bodies instead of redacting them
+	LogUnredacted bool
+
+	// notified about every attempt of a request, nil disables it
+	Observer types.Observer
+
+	// client-side limits shared by all requests of the Transport, zero disables a limit
+	RequestsPerSecond   float64
+	CharactersPerSecond float64
+	MaxInFlight         int
+
+	limiterOnce sync.Once
+	limiter     *limiter
 }
 
 // NewTransport returns a new Transport with the given server url, headers, timeout and retries.
@@ -30,20 +55,20 @@ func NewTransport(serverUrl string, headers map[string]string, timeOut time.Dura
 		Headers:   headers,
 		TimeOut:   timeOut,
 		Retries:   retries,
+		Backoff:   DefaultRetryBackoff,
 	}
 }
 
 // Client returns a new http.Client with the Transport as the underlying transport.
+// The timeout of the client covers all retries of a request only if AttemptTimeout is set,
+// otherwise TimeOut applies to each attempt and a request may take up to
+// (Retries+1)×TimeOut plus the delays between attempts; use the context to bound it.
 func (t *Transport) Client() *http.Client {
-	return httpretry.NewCustomClient(&http.Client{
-		Transport: t,
-		Timeout:   t.TimeOut,
-	},
-		httpretry.WithMaxRetryCount(t.Retries),
-		httpretry.WithRetryPolicy(func(statusCode int, err error) bool {
-			return err != nil || statusCode >= 500 || statusCode == 429 || statusCode == 0
-		}),
-	)
+	client := &http.Client{Transport: t}
+	if t.AttemptTimeout > 0 {
+		client.Timeout = t.TimeOut
+	}
+	return client
 }
 
 // transport returns the underlying RoundTripper, defaulting to http.DefaultTransport.
@@ -56,9 +81,17 @@ func (t *Transport) transport() http.RoundTripper {
 
 // RoundTrip executes a single HTTP transaction, returning a Response for the provided Request.
 // Sets prior defined headers and adds the host url to the request url.
+// Paths starting with /v3/ are sent to the server url without its /v2 suffix.
+// The request waits for the rate limits and the maximum number of requests in flight,
+// and is retried as configured, see retryRoundTrip.
 func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 	req := r.Clone(r.Context())
-	fullURL := fmt.Sprintf("%s%s?%s", t.ServerUrl, req.URL.Path, req.URL.RawQuery)
+	serverUrl := t.ServerUrl
+	if strings.HasPrefix(req.URL.Path, "/v3/") {
+		// paths of the v3 API replace the version of the server url
+		serverUrl = strings.TrimSuffix(strings.TrimSuffix(serverUrl, "/"), "/v2")
+	}
+	fullURL := fmt.Sprintf("%s%s?%s", serverUrl, req.URL.Path, req.URL.RawQuery)
 	u, err := url.Parse(fullURL)
 	if err != nil {
 		return &http.Response{}, err
@@ -67,5 +100,5 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 	for k, v := range t.Headers {
 		req.Header.Set(k, v)
 	}
-	return t.transport().RoundTrip(req)
+	return t.retryRoundTrip(req)
 }
@@ -0,0 +1,154 @@
+// Command genconsts generates the language constants of the consts package from a saved
+// response of the DeepL /languages endpoint, so updates are reproducible offline.
+//
+// The input is a JSON object with the source and target languages as returned by
+// /languages?type=source and /languages?type=target:
+//
+//	{"source": [{"language": "DE", "name": "German"}], "target": [{"language": "DE", "name": "German", "supports_formality": true}]}
+//
+// Usage:
+//
+//	go run ./cmd/genconsts -input consts/languages.json -output consts/constants.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// nameOverrides are the constant names of languages whose names from DeepL are ambiguous or
+// would change the names of existing constants.
+var nameOverrides = map[string]string{
+	"EN-GB": "EnglishGB",
+	"EN-US": "EnglishUS",
+	"NB":    "Norwegian",
+	"PT-BR": "PortugueseBrazilian",
+	"PT-PT": "Portuguese",
+	"ZH":    "Chinese",
+}
+
+// language is a language as returned by /languages. The consts package is not imported,
+// so the generator works while the generated file does not compile.
+type language struct {
+	Language          string `json:"language"`
+	Name              string `json:"name"`
+	SupportsFormality bool   `json:"supports_formality"`
+}
+
+// languages is the content of the input file.
+type languages struct {
+	Source []language `json:"source"`
+	Target []language `json:"target"`
+}
+
+func main() {
+	input := flag.String("input", "languages.json", "saved /languages responses")
+	output := flag.String("output", "constants.go", "generated file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(data, filepath.Base(*input))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the formatted source of the language constants for the JSON input read from inputName.
+func generate(data []byte, inputName string) ([]byte, error) {
+	var langs languages
+	if err := json.Unmarshal(data, &langs); err != nil {
+		return nil, fmt.Errorf("%s: %w", inputName, err)
+	}
+	if len(langs.Source) == 0 || len(langs.Target) == 0 {
+		return nil, fmt.Errorf("%s: source and target languages are required", inputName)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by genconsts from %s; DO NOT EDIT.\n\npackage consts\n", inputName)
+	if err := writeLanguages(&buf, "SourceLang", langs.Source, false); err != nil {
+		return nil, err
+	}
+	if err := writeLanguages(&buf, "TargetLang", langs.Target, true); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// writeLanguages writes the constants of typ and the list of all of them. For target languages
+// the list of languages supporting formality is written as well.
+func writeLanguages(buf *bytes.Buffer, typ string, langs []language, target bool) error {
+	langs = append([]language(nil), langs...)
+	sort.Slice(langs, func(i, j int) bool { return langs[i].Language < langs[j].Language })
+	names := make([]string, len(langs))
+	seen := map[string]string{}
+	for i, lang := range langs {
+		names[i] = typ + constName(lang)
+		if code, ok := seen[names[i]]; ok {
+			return fmt.Errorf("%s and %s both result in %s, add a name override", code, lang.Language, names[i])
+		}
+		seen[names[i]] = lang.Language
+	}
+
+	fmt.Fprintf(buf, "\nconst (\n")
+	for i, lang := range langs {
+		comment := lang.Name
+		if target && lang.SupportsFormality {
+			comment += ", supports formality"
+		}
+		fmt.Fprintf(buf, "\t%s %s = %q // %s\n", names[i], typ, strings.ToUpper(lang.Language), comment)
+	}
+	fmt.Fprintf(buf, ")\n")
+
+	plural := strings.TrimSuffix(typ, "Lang") + "Languages"
+	fmt.Fprintf(buf, "\n// %s are the %s languages known to this version of the library.\n", plural, strings.ToLower(strings.TrimSuffix(typ, "Lang")))
+	fmt.Fprintf(buf, "// Use deepl.LanguageCatalog for the languages currently supported by DeepL.\n")
+	fmt.Fprintf(buf, "var %s = []%s{\n", plural, typ)
+	for _, name := range names {
+		fmt.Fprintf(buf, "\t%s,\n", name)
+	}
+	fmt.Fprintf(buf, "}\n")
+
+	if target {
+		fmt.Fprintf(buf, "\n// FormalityTargetLanguages are the target languages known to support formality.\n")
+		fmt.Fprintf(buf, "var FormalityTargetLanguages = []TargetLang{\n")
+		for i, lang := range langs {
+			if lang.SupportsFormality {
+				fmt.Fprintf(buf, "\t%s,\n", names[i])
+			}
+		}
+		fmt.Fprintf(buf, "}\n")
+	}
+	return nil
+}
+
+// constName returns the name of the constant of lang without type prefix, e.g. "Norwegian" for "Norwegian (Bokmål)".
+func constName(lang language) string {
+	if name, ok := nameOverrides[strings.ToUpper(lang.Language)]; ok {
+		return name
+	}
+	name, _, _ := strings.Cut(lang.Name, "(")
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	if b.Len() == 0 {
+		return strings.ReplaceAll(strings.ToUpper(lang.Language), "-", "")
+	}
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestGenerate_UpToDate fails if consts/constants.go was edited by hand or not regenerated after languages.json changed.
+func TestGenerate_UpToDate(t *testing.T) {
+	data, err := os.ReadFile("../../consts/languages.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate(data, "languages.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("../../consts/constants.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Error("consts/constants.go is out of date, run go generate ./consts")
+	}
+}
+
+func TestGenerate_Names(t *testing.T) {
+	input := `{
+		"source": [{"language": "EN", "name": "English"}],
+		"target": [
+			{"language": "EN-US", "name": "English (American)"},
+			{"language": "PT-BR", "name": "Portuguese (Brazilian)", "supports_formality": true},
+			{"language": "ZH-HANT", "name": "Chinese (traditional)"}
+		]
+	}`
+	src, err := generate([]byte(input), "test.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ignore the alignment of gofmt
+	normalized := strings.Join(strings.Fields(string(src)), " ")
+	for _, want := range []string{
+		`SourceLangEnglish SourceLang = "EN"`,
+		`TargetLangEnglishUS TargetLang = "EN-US"`,
+		`TargetLangPortugueseBrazilian TargetLang = "PT-BR" // Portuguese (Brazilian), supports formality`,
+		`TargetLangChinese TargetLang = "ZH-HANT"`,
+	} {
+		if !strings.Contains(normalized, want) {
+			t.Errorf("generated source is missing %q:\n%s", want, src)
+		}
+	}
+
+	duplicate := `{"source": [{"language": "EN", "name": "English"}], "target": [{"language": "EN-AU", "name": "English (Australian)"}, {"language": "EN-CA", "name": "English (Canadian)"}]}`
+	if _, err := generate([]byte(duplicate), "test.json"); err == nil {
+		t.Error("expected an error for two languages with the same constant name")
+	}
+}
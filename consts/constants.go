@@ -1,87 +1,153 @@
-package consts
+// Code generated by genconsts from languages.json; DO NOT EDIT.
 
-type Formality string
-type SourceLang string
-type TargetLang string
-type DocumentStatusCode string
+package consts
 
 const (
-	Default    Formality = "default"
-	More       Formality = "more"
-	Less       Formality = "less"
-	PreferMore Formality = "prefer_more"
-	PreferLess Formality = "prefer_less"
+	SourceLangBulgarian  SourceLang = "BG" // Bulgarian
+	SourceLangCzech      SourceLang = "CS" // Czech
+	SourceLangDanish     SourceLang = "DA" // Danish
+	SourceLangGerman     SourceLang = "DE" // German
+	SourceLangGreek      SourceLang = "EL" // Greek
+	SourceLangEnglish    SourceLang = "EN" // English
+	SourceLangSpanish    SourceLang = "ES" // Spanish
+	SourceLangEstonian   SourceLang = "ET" // Estonian
+	SourceLangFinnish    SourceLang = "FI" // Finnish
+	SourceLangFrench     SourceLang = "FR" // French
+	SourceLangHungarian  SourceLang = "HU" // Hungarian
+	SourceLangIndonesian SourceLang = "ID" // Indonesian
+	SourceLangItalian    SourceLang = "IT" // Italian
+	SourceLangJapanese   SourceLang = "JA" // Japanese
+	SourceLangKorean     SourceLang = "KO" // Korean
+	SourceLangLithuanian SourceLang = "LT" // Lithuanian
+	SourceLangLatvian    SourceLang = "LV" // Latvian
+	SourceLangNorwegian  SourceLang = "NB" // Norwegian (Bokmål)
+	SourceLangDutch      SourceLang = "NL" // Dutch
+	SourceLangPolish     SourceLang = "PL" // Polish
+	SourceLangPortuguese SourceLang = "PT" // Portuguese
+	SourceLangRomanian   SourceLang = "RO" // Romanian
+	SourceLangRussian    SourceLang = "RU" // Russian
+	SourceLangSlovak     SourceLang = "SK" // Slovak
+	SourceLangSlovenian  SourceLang = "SL" // Slovenian
+	SourceLangSwedish    SourceLang = "SV" // Swedish
+	SourceLangTurkish    SourceLang = "TR" // Turkish
+	SourceLangUkrainian  SourceLang = "UK" // Ukrainian
+	SourceLangChinese    SourceLang = "ZH" // Chinese
 )
 
-const (
-	DocumentStatusQueued      DocumentStatusCode = "queued"
-	DocumentStatusTranslating DocumentStatusCode = "translating"
-	DocumentStatusError       DocumentStatusCode = "error"
-	DocumentStatusDone        DocumentStatusCode = "done"
-)
+// SourceLanguages are the source languages known to this version of the library.
+// Use deepl.LanguageCatalog for the languages currently supported by DeepL.
+var SourceLanguages = []SourceLang{
+	SourceLangBulgarian,
+	SourceLangCzech,
+	SourceLangDanish,
+	SourceLangGerman,
+	SourceLangGreek,
+	SourceLangEnglish,
+	SourceLangSpanish,
+	SourceLangEstonian,
+	SourceLangFinnish,
+	SourceLangFrench,
+	SourceLangHungarian,
+	SourceLangIndonesian,
+	SourceLangItalian,
+	SourceLangJapanese,
+	SourceLangKorean,
+	SourceLangLithuanian,
+	SourceLangLatvian,
+	SourceLangNorwegian,
+	SourceLangDutch,
+	SourceLangPolish,
+	SourceLangPortuguese,
+	SourceLangRomanian,
+	SourceLangRussian,
+	SourceLangSlovak,
+	SourceLangSlovenian,
+	SourceLangSwedish,
+	SourceLangTurkish,
+	SourceLangUkrainian,
+	SourceLangChinese,
+}
 
 const (
-	SourceLangBulgarian  SourceLang = "BG"
-	SourceLangCzech      SourceLang = "CS"
-	SourceLangDanish     SourceLang = "DA"
-	SourceLangGreek      SourceLang = "EL"
-	SourceLangGerman     SourceLang = "DE"
-	SourceLangEnglish    SourceLang = "EN"
-	SourceLangSpanish    SourceLang = "ES"
-	SourceLangEstonian   SourceLang = "ET"
-	SourceLangFinnish    SourceLang = "FI"
-	SourceLangFrench     SourceLang = "FR"
-	SourceLangHungarian  SourceLang = "HU"
-	SourceLangIndonesian SourceLang = "ID"
-	SourceLangItalian    SourceLang = "IT"
-	SourceLangJapanese   SourceLang = "JA"
-	SourceLangKorean     SourceLang = "KO"
-	SourceLangLithuanian SourceLang = "LT"
-	SourceLangLatvian    SourceLang = "LV"
-	SourceLangNorwegian  SourceLang = "NB"
-	SourceLangDutch      SourceLang = "NL"
-	SourceLangPolish     SourceLang = "PL"
-	SourceLangPortuguese SourceLang = "PT"
-	SourceLangRomanian   SourceLang = "RO"
-	SourceLangRussian    SourceLang = "RU"
-	SourceLangSlovak     SourceLang = "SK"
-	SourceLangSlovenian  SourceLang = "SL"
-	SourceLangSwedish    SourceLang = "SV"
-	SourceLangTurkish    SourceLang = "TR"
-	SourceLangUkrainian  SourceLang = "UK"
-	SourceLangChinese    SourceLang = "ZH"
+	TargetLangBulgarian           TargetLang = "BG"    // Bulgarian
+	TargetLangCzech               TargetLang = "CS"    // Czech
+	TargetLangDanish              TargetLang = "DA"    // Danish
+	TargetLangGerman              TargetLang = "DE"    // German, supports formality
+	TargetLangGreek               TargetLang = "EL"    // Greek
+	TargetLangEnglishGB           TargetLang = "EN-GB" // English (British)
+	TargetLangEnglishUS           TargetLang = "EN-US" // English (American)
+	TargetLangSpanish             TargetLang = "ES"    // Spanish, supports formality
+	TargetLangEstonian            TargetLang = "ET"    // Estonian
+	TargetLangFinnish             TargetLang = "FI"    // Finnish
+	TargetLangFrench              TargetLang = "FR"    // French, supports formality
+	TargetLangHungarian           TargetLang = "HU"    // Hungarian
+	TargetLangIndonesian          TargetLang = "ID"    // Indonesian
+	TargetLangItalian             TargetLang = "IT"    // Italian, supports formality
+	TargetLangJapanese            TargetLang = "JA"    // Japanese, supports formality
+	TargetLangKorean              TargetLang = "KO"    // Korean
+	TargetLangLithuanian          TargetLang = "LT"    // Lithuanian
+	TargetLangLatvian             TargetLang = "LV"    // Latvian
+	TargetLangNorwegian           TargetLang = "NB"    // Norwegian (Bokmål)
+	TargetLangDutch               TargetLang = "NL"    // Dutch, supports formality
+	TargetLangPolish              TargetLang = "PL"    // Polish, supports formality
+	TargetLangPortugueseBrazilian TargetLang = "PT-BR" // Portuguese (Brazilian), supports formality
+	TargetLangPortuguese          TargetLang = "PT-PT" // Portuguese (European), supports formality
+	TargetLangRomanian            TargetLang = "RO"    // Romanian
+	TargetLangRussian             TargetLang = "RU"    // Russian, supports formality
+	TargetLangSlovak              TargetLang = "SK"    // Slovak
+	TargetLangSlovenian           TargetLang = "SL"    // Slovenian
+	TargetLangSwedish             TargetLang = "SV"    // Swedish
+	TargetLangTurkish             TargetLang = "TR"    // Turkish
+	TargetLangUkrainian           TargetLang = "UK"    // Ukrainian
+	TargetLangChinese             TargetLang = "ZH"    // Chinese (simplified)
 )
 
-const (
-	TargetLangBulgarian           TargetLang = "BG"
-	TargetLangCzech               TargetLang = "CS"
-	TargetLangDanish              TargetLang = "DA"
-	TargetLangGreek               TargetLang = "EL"
-	TargetLangGerman              TargetLang = "DE"
-	TargetLangEnglishUS           TargetLang = "EN-US"
-	TargetLangEnglishGB           TargetLang = "EN-GB"
-	TargetLangSpanish             TargetLang = "ES"
-	TargetLangEstonian            TargetLang = "ET"
-	TargetLangFinnish             TargetLang = "FI"
-	TargetLangFrench              TargetLang = "FR"
-	TargetLangHungarian           TargetLang = "HU"
-	TargetLangIndonesian          TargetLang = "ID"
-	TargetLangItalian             TargetLang = "IT"
-	TargetLangJapanese            TargetLang = "JA"
-	TargetLangKorean              TargetLang = "KO"
-	TargetLangLithuanian          TargetLang = "LT"
-	TargetLangLatvian             TargetLang = "LV"
-	TargetLangNorwegian           TargetLang = "NB"
-	TargetLangDutch               TargetLang = "NL"
-	TargetLangPolish              TargetLang = "PL"
-	TargetLangPortugueseBrazilian TargetLang = "PT-BR"
-	TargetLangPortuguese          TargetLang = "PT-PT"
-	TargetLangRomanian            TargetLang = "RO"
-	TargetLangRussian             TargetLang = "RU"
-	TargetLangSlovak              TargetLang = "SK"
-	TargetLangSlovenian           TargetLang = "SL"
-	TargetLangSwedish             TargetLang = "SV"
-	TargetLangTurkish             TargetLang = "TR"
-	TargetLangUkrainian           TargetLang = "UK"
-	TargetLangChinese             TargetLang = "ZH"
-)
+// TargetLanguages are the target languages known to this version of the library.
+// Use deepl.LanguageCatalog for the languages currently supported by DeepL.
+var TargetLanguages = []TargetLang{
+	TargetLangBulgarian,
+	TargetLangCzech,
+	TargetLangDanish,
+	TargetLangGerman,
+	TargetLangGreek,
+	TargetLangEnglishGB,
+	TargetLangEnglishUS,
+	TargetLangSpanish,
+	TargetLangEstonian,
+	TargetLangFinnish,
+	TargetLangFrench,
+	TargetLangHungarian,
+	TargetLangIndonesian,
+	TargetLangItalian,
+	TargetLangJapanese,
+	TargetLangKorean,
+	TargetLangLithuanian,
+	TargetLangLatvian,
+	TargetLangNorwegian,
+	TargetLangDutch,
+	TargetLangPolish,
+	TargetLangPortugueseBrazilian,
+	TargetLangPortuguese,
+	TargetLangRomanian,
+	TargetLangRussian,
+	TargetLangSlovak,
+	TargetLangSlovenian,
+	TargetLangSwedish,
+	TargetLangTurkish,
+	TargetLangUkrainian,
+	TargetLangChinese,
+}
+
+// FormalityTargetLanguages are the target languages known to support formality.
+var FormalityTargetLanguages = []TargetLang{
+	TargetLangGerman,
+	TargetLangSpanish,
+	TargetLangFrench,
+	TargetLangItalian,
+	TargetLangJapanese,
+	TargetLangDutch,
+	TargetLangPolish,
+	TargetLangPortugueseBrazilian,
+	TargetLangPortuguese,
+	TargetLangRussian,
+}
@@ -0,0 +1,77 @@
+// Package consts contains the languages and other constant values of the DeepL API.
+//
+// The language constants in constants.go are generated from languages.json, a saved response
+// of the /languages endpoint, see cmd/genconsts.
+package consts
+
+//go:generate go run ../cmd/genconsts -input languages.json -output constants.go
+
+type Formality string
+type SourceLang string
+type TargetLang string
+type DocumentStatusCode string
+type DocumentEventType string
+type Operation string
+
+const (
+	Default    Formality = "default"
+	More       Formality = "more"
+	Less       Formality = "less"
+	PreferMore Formality = "prefer_more"
+	PreferLess Formality = "prefer_less"
+)
+
+const (
+	DocumentStatusQueued      DocumentStatusCode = "queued"
+	DocumentStatusTranslating DocumentStatusCode = "translating"
+	DocumentStatusError       DocumentStatusCode = "error"
+	DocumentStatusDone        DocumentStatusCode = "done"
+)
+
+const (
+	DocumentEventUploaded    DocumentEventType = "uploaded"
+	DocumentEventQueued      DocumentEventType = "queued"
+	DocumentEventTranslating DocumentEventType = "translating"
+	DocumentEventDone        DocumentEventType = "done"
+	DocumentEventDownloading DocumentEventType = "downloading"
+	DocumentEventError       DocumentEventType = "error"
+)
+
+const (
+	OperationTranslateText        Operation = "translate_text"
+	OperationTranslateDocument    Operation = "translate_document"
+	OperationTranslateDocuments   Operation = "translate_documents"
+	OperationUploadDocument       Operation = "upload_document"
+	OperationWaitDocument         Operation = "wait_document"
+	OperationDownloadDocument     Operation = "download_document"
+	OperationCreateGlossary       Operation = "create_glossary"
+	OperationGetGlossaries        Operation = "get_glossaries"
+	OperationGetGlossary          Operation = "get_glossary"
+	OperationGetGlossaryEntries   Operation = "get_glossary_entries"
+	OperationDeleteGlossary       Operation = "delete_glossary"
+	OperationGetUsage             Operation = "get_usage"
+	OperationGetLanguages         Operation = "get_languages"
+	OperationGetGlossaryLanguages Operation = "get_glossary_languages"
+	OperationSyncGlossary         Operation = "sync_glossary"
+	OperationFindGlossary         Operation = "find_glossary"
+	OperationWaitGlossary         Operation = "wait_glossary"
+
+	OperationCreateMultilingualGlossary Operation = "create_multilingual_glossary"
+	OperationGetMultilingualGlossaries  Operation = "get_multilingual_glossaries"
+	OperationGetMultilingualGlossary    Operation = "get_multilingual_glossary"
+	OperationUpdateMultilingualGlossary Operation = "update_multilingual_glossary"
+	OperationDeleteMultilingualGlossary Operation = "delete_multilingual_glossary"
+	OperationGetDictionaryEntries       Operation = "get_dictionary_entries"
+	OperationReplaceDictionary          Operation = "replace_dictionary"
+	OperationDeleteDictionary           Operation = "delete_dictionary"
+)
+
+// SourceLangAuto lets DeepL detect the source language, see types.Translation.DetectedSourceLanguage.
+const SourceLangAuto SourceLang = ""
+
+// GlossaryLanguages are the languages known to support glossaries, in any combination of two different languages.
+var GlossaryLanguages = []SourceLang{
+	SourceLangGerman, SourceLangEnglish, SourceLangSpanish, SourceLangFrench, SourceLangItalian,
+	SourceLangJapanese, SourceLangDutch, SourceLangPolish, SourceLangPortuguese, SourceLangRussian,
+	SourceLangChinese,
+}
@@ -0,0 +1,45 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+)
+
+func TestTranslator_ContextCancellation(t *testing.T) {
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/translate":
+			r.ParseForm()
+			<-r.Context().Done()
+		case "/v2/document":
+			w.Write([]byte(`{"document_id":"abc","document_key":"key"}`))
+		default:
+			w.Write([]byte(`{"document_id":"abc","status":"translating","seconds_remaining":60}`))
+		}
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := translator.TranslateTextAsync([]string{"proton beam"}, consts.SourceLangEnglish, consts.TargetLangGerman)(ctx)
+	if !errors.Is(err, ErrCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("translate: got %v, want ErrCanceled", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = translator.TranslateDocumentAsync(consts.SourceLangEnglish, consts.TargetLangGerman, strings.NewReader("proton beam"), io.Discard)(ctx)
+	if !errors.Is(err, ErrCanceled) {
+		t.Errorf("document: got %v, want ErrCanceled", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("document polling was not aborted on cancellation")
+	}
+}
@@ -4,23 +4,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
-	"mime/multipart"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/anthdm/tasker"
 	"github.com/carlmjohnson/requests"
 	"github.com/fatih/structs"
-	"github.com/google/uuid"
 	"github.com/hsedr/deepl-golang/consts"
 	"github.com/hsedr/deepl-golang/types"
 )
 
 type Translator struct {
 	HttpClient *http.Client
+	options    types.TranslatorOptions
+	quota      *quotaGuard
+
+	// languagesMu guards the refresh state of languages, the catalog itself is safe for concurrent use
+	languagesMu         sync.Mutex
+	languages           *LanguageCatalog
+	languagesRefreshing bool
+	languagesFailed     time.Time
+	languagesErr        error
 }
 
 func NewTranslator(authKey string, opts ...func(*types.TranslatorOptions) error) (*Translator, error) {
@@ -30,7 +38,9 @@ func NewTranslator(authKey string, opts ...func(*types.TranslatorOptions) error)
 	}
 	options.Headers["Authorization"] = fmt.Sprint("DeepL-Auth-Key ", authKey)
 	for _, opt := range opts {
-		opt(&options)
+		if err := opt(&options); err != nil {
+			return &Translator{}, err
+		}
 	}
 	if options.ServerURL == "" {
 		if IsFreeAccountAuthKey(authKey) {
@@ -39,9 +49,26 @@ func NewTranslator(authKey string, opts ...func(*types.TranslatorOptions) error)
 			options.ServerURL = "https://api.deepl.com/v2"
 		}
 	}
-	return &Translator{
-		HttpClient: NewTransport(options.ServerURL, options.Headers, options.TimeOut, options.Retries).Client(),
-	}, nil
+	transport := NewTransport(options.ServerURL, options.Headers, options.TimeOut, options.Retries)
+	transport.AttemptTimeout = options.AttemptTimeout
+	if options.RetryBackoff != (types.RetryBackoff{}) {
+		transport.Backoff = options.RetryBackoff
+	}
+	transport.Observer = options.Observer
+	transport.Logger = options.Logger
+	transport.LogUnredacted = options.LogUnredacted
+	transport.RequestsPerSecond = options.RequestsPerSecond
+	transport.CharactersPerSecond = options.CharactersPerSecond
+	transport.MaxInFlight = options.MaxInFlight
+	translator := &Translator{
+		HttpClient: transport.Client(),
+		options:    options,
+		languages:  NewLanguageCatalog(),
+	}
+	if options.Quota != nil {
+		translator.quota = &quotaGuard{options: *options.Quota}
+	}
+	return translator, nil
 }
 
 func WithServerURL(serverURL string) func(*types.TranslatorOptions) error {
@@ -65,6 +92,31 @@ func WithRetries(retries int) func(*types.TranslatorOptions) error {
 	}
 }
 
+// WithRetryBackoff sets the delays between retries of failed requests. The delay starts at initial and is
+// multiplied with every retry up to max, randomized by up to ±jitter of its value.
+// A Retry-After header sent by DeepL takes precedence.
+func WithRetryBackoff(initial, max time.Duration, multiplier, jitter float64) func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		if initial <= 0 || max < initial || multiplier < 1 || jitter < 0 || jitter > 1 {
+			return fmt.Errorf("%w: invalid retry backoff", ErrInvalidOption)
+		}
+		options.RetryBackoff = types.RetryBackoff{Initial: initial, Max: max, Multiplier: multiplier, Jitter: jitter}
+		return nil
+	}
+}
+
+// WithAttemptTimeout limits a single attempt of a request, while WithTimeOut limits all attempts together.
+func WithAttemptTimeout(timeout time.Duration) func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		if timeout <= 0 {
+			return fmt.Errorf("%w: attempt timeout must be positive", ErrInvalidOption)
+		}
+		options.AttemptTimeout = timeout
+		return nil
+	}
+}
+
+// WithTimeOut limits every attempt of a request, or all attempts together if WithAttemptTimeout is used as well.
 func WithTimeOut(timeout time.Duration) func(*types.TranslatorOptions) error {
 	return func(options *types.TranslatorOptions) error {
 		options.TimeOut = timeout
@@ -81,347 +133,352 @@ func WithHeaders(headers map[string]string) func(*types.TranslatorOptions) error
 	}
 }
 
-func (d *Translator) TranslateTextAsync(
-	text []string,
-	sourceLang consts.SourceLang,
-	targetLang consts.TargetLang,
-	opts ...func(*types.TextTranslateOptions) error,
-) tasker.TaskFunc[[]types.Translation] {
-	return func(ctx context.Context) ([]types.Translation, error) {
-		var response types.Translations
-		options := types.TextTranslateOptions{}
-		for _, opt := range opts {
-			opt(&options)
-			break
-		}
-		err := requests.
-			URL("/translate").
-			Client(d.HttpClient).
-			ContentType("application/x-www-form-urlencoded").
-			Param("text", text...).
-			Param("source_lang", string(sourceLang)).
-			Param("target_lang", string(targetLang)).
-			Config(func(rb *requests.Builder) {
-				for k, v := range structToMap(options) {
-					rb.Param(k, v)
-				}
-			}).
-			ToJSON(&response).
-			Fetch(context.Background())
-		if err != nil {
-			return response.Translations, err
+// WithPollStrategy sets the default strategy for polling the status of document translations.
+func WithPollStrategy(strategy types.PollStrategy) func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		if strategy == nil {
+			return fmt.Errorf("%w: poll strategy must not be nil", ErrInvalidOption)
 		}
-		return response.Translations, nil
+		options.PollStrategy = strategy
+		return nil
 	}
 }
 
-func WithTextTranslateOptions(options types.TextTranslateOptions) func(*types.TextTranslateOptions) error {
-	return func(opts *types.TextTranslateOptions) error {
-		opts = &options
+// WithMaxPollWait sets the default maximum time to wait for a document translation to finish.
+func WithMaxPollWait(maxWait time.Duration) func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		if maxWait <= 0 {
+			return fmt.Errorf("%w: max poll wait must be positive", ErrInvalidOption)
+		}
+		options.MaxPollWait = maxWait
 		return nil
 	}
 }
 
-// TranslateDocumentAsync translates a document and returns a task that can be awaited.
-func (d *Translator) TranslateDocumentAsync(
-	s consts.SourceLang,
-	t consts.TargetLang,
-	f io.Reader,
-	w io.Writer,
-	opts ...func(*types.DocumentTranslateOptions) error,
-) tasker.TaskFunc[types.DocumentStatus] {
-	return func(ctx context.Context) (types.DocumentStatus, error) {
-		var status types.DocumentStatus
-		options := types.DocumentTranslateOptions{}
-		for _, opt := range opts {
-			opt(&options)
-			break
-		}
-		if options.FileName == "" {
-			options.FileName = uuid.New().String()
+// WithTextChunking sets the maximum number of texts and the maximum size in bytes of a single
+// translate request. Larger calls of TranslateText are split into several requests.
+func WithTextChunking(maxTexts, maxBytes int) func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		if maxTexts <= 0 || maxBytes <= 0 {
+			return fmt.Errorf("%w: text chunking limits must be positive", ErrInvalidOption)
 		}
-		doc, err := tasker.Spawn(d.uploadDocumentAsync(s, t, f, options)).Await()
-		if err != nil {
-			return status, err
+		options.MaxTextsPerRequest = maxTexts
+		options.MaxRequestBytes = maxBytes
+		return nil
+	}
+}
+
+// WithChunkConcurrency sets how many requests of a split text translation are sent concurrently.
+// The default of 1 sends them sequentially.
+func WithChunkConcurrency(n int) func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		if n <= 0 {
+			return fmt.Errorf("%w: chunk concurrency must be positive", ErrInvalidOption)
 		}
-		status, err = tasker.Spawn(d.isDocumentTranslationCompleteAsync(&doc)).Await()
-		if err != nil {
-			return status, err
+		options.ChunkConcurrency = n
+		return nil
+	}
+}
+
+// WithRateLimit limits the requests and the translated characters per second of all calls of the Translator.
+// Requests wait until they are within the limits; zero disables a limit.
+func WithRateLimit(requestsPerSecond, charactersPerSecond float64) func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		if requestsPerSecond < 0 || charactersPerSecond < 0 {
+			return fmt.Errorf("%w: rate limits must not be negative", ErrInvalidOption)
 		}
-		_, err = tasker.Spawn(d.downloadDocumentAsync(&doc, options.OutputFile)).Await()
-		if err != nil {
-			return status, err
+		options.RequestsPerSecond = requestsPerSecond
+		options.CharactersPerSecond = charactersPerSecond
+		return nil
+	}
+}
+
+// WithMaxInFlight limits the number of requests of the Translator that are sent concurrently.
+func WithMaxInFlight(n int) func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		if n <= 0 {
+			return fmt.Errorf("%w: max in flight must be positive", ErrInvalidOption)
 		}
-		return status, nil
+		options.MaxInFlight = n
+		return nil
 	}
 }
 
-func WithDocumentTranslateOptions(options types.DocumentTranslateOptions) func(*types.DocumentTranslateOptions) error {
-	return func(opts *types.DocumentTranslateOptions) error {
-		opts = &options
+// WithLogger logs method, path, status, duration, attempt and request id of every request to logger.
+// Successful requests are logged at debug level, failures at warn or error level.
+// Auth keys, document keys and texts are redacted, see WithUnredactedLogging.
+func WithLogger(logger *slog.Logger) func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		options.Logger = logger
 		return nil
 	}
 }
 
-// uploadDocumentAsync uploads a document to the DeepL API and returns a task that can be awaited.
-func (d *Translator) uploadDocumentAsync(
-	s consts.SourceLang,
-	t consts.TargetLang,
-	file io.Reader,
-	options types.DocumentTranslateOptions,
-) tasker.TaskFunc[types.DocumentHandle] {
-	return func(ctx context.Context) (types.DocumentHandle, error) {
-		var doc types.DocumentHandle
-		boundary := strings.Replace(uuid.New().String(), "-", "", -1)
-		contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
-		err := requests.
-			URL("/document").
-			Client(d.HttpClient).
-			BodyWriter(func(w io.Writer) error {
-				bodyWriter := multipart.NewWriter(w)
-				bodyWriter.SetBoundary(boundary)
-				bodyWriter.WriteField("source_lang", string(s))
-				bodyWriter.WriteField("target_lang", string(t))
-				bodyWriter.WriteField("glossary_id", options.GlossaryID)
-				fileWriter, err := bodyWriter.CreateFormFile("file", options.FileName)
-				if err != nil {
-					return err
-				}
-				io.Copy(fileWriter, file)
-				bodyWriter.Close()
-				return nil
-			}).
-			ContentType(contentType).
-			ToJSON(&doc).
-			Fetch(context.Background())
-		if err != nil {
-			return doc, err
-		}
-		return doc, nil
-	}
-}
-
-// checkDocumentStatusAsync checks the status of a document translation and returns a task that can be awaited.
-func (d *Translator) checkDocumentStatusAsync(doc *types.DocumentHandle) tasker.TaskFunc[types.DocumentStatus] {
-	return func(ctx context.Context) (types.DocumentStatus, error) {
-		path := fmt.Sprintf("/document/%s", doc.DocumentID)
-		var res types.DocumentStatus
-		err := requests.
-			URL(path).
-			Client(d.HttpClient).
-			ContentType("application/x-www-form-urlencoded").
-			Param("document_key", doc.DocumentKey).
-			ToJSON(&res).
-			Fetch(context.Background())
-		if err != nil {
-			return res, err
-		}
-		return res, nil
+// WithUnredactedLogging logs auth keys, document keys and form encoded request bodies, for debugging only.
+func WithUnredactedLogging() func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		options.LogUnredacted = true
+		return nil
 	}
 }
 
-// isDocumentTranslationCompleteAsync checks if a document translation is complete and returns a task that can be awaited.
-// If the translation is not complete, the task will wait for half the estimated time remaining and check again.
-func (d *Translator) isDocumentTranslationCompleteAsync(doc *types.DocumentHandle) tasker.TaskFunc[types.DocumentStatus] {
-	return func(ctx context.Context) (types.DocumentStatus, error) {
-		status, err := tasker.Spawn(d.checkDocumentStatusAsync(doc)).Await()
-		if err != nil {
-			return status, err
-		}
-		for !status.Done() && status.Ok() {
-			secs := float64(status.SecondsRemaining/2 + 1)
-			time.Sleep(time.Duration(secs) * time.Second)
-			status, err = tasker.Spawn(d.checkDocumentStatusAsync(doc)).Await()
-			if err != nil {
-				return status, err
-			}
-		}
-		if !status.Ok() {
-			return status, errors.New("docoument translation failed, status not ok")
+// WithCache caches text translations in c, so repeated translations of the same text with
+// the same languages and options are not sent to DeepL again.
+func WithCache(c types.Cache) func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		options.Cache = c
+		return nil
+	}
+}
+
+// TranslateText translates the given texts and returns one translation per text.
+// Texts exceeding the API limits per request are split into several requests,
+// see WithTextChunking. With consts.SourceLangAuto DeepL detects the source language of every text.
+func (d *Translator) TranslateText(
+	ctx context.Context,
+	text []string,
+	sourceLang consts.SourceLang,
+	targetLang consts.TargetLang,
+	opts ...func(*types.TextTranslateOptions) error,
+) (_ []types.Translation, err error) {
+	ctx, end := d.observe(ctx, consts.OperationTranslateText)
+	defer end(&err)
+	options := types.TextTranslateOptions{}
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return nil, err
 		}
-		return status, err
-	}
-}
-
-// downloadDocumentAsync downloads a document translation and returns a task that can be awaited.
-func (d *Translator) downloadDocumentAsync(doc *types.DocumentHandle, file io.Writer) tasker.TaskFunc[bool] {
-	return func(ctx context.Context) (bool, error) {
-		path := fmt.Sprintf("/document/%s/result", doc.DocumentID)
-		err := requests.
-			URL(path).
-			Client(d.HttpClient).
-			ContentType("application/x-www-form-urlencoded").
-			Param("document_key", doc.DocumentKey).
-			ToWriter(file).
-			Fetch(context.Background())
-		if err != nil {
-			return false, err
+	}
+	if catalog := d.languageCatalog(ctx); catalog != nil {
+		if err := catalog.ValidateTranslation(sourceLang, targetLang, options.Formality); err != nil {
+			return nil, err
 		}
-		return true, nil
 	}
+	params := url.Values{}
+	if sourceLang != consts.SourceLangAuto {
+		params.Set("source_lang", string(sourceLang))
+	}
+	params.Set("target_lang", string(targetLang))
+	for k, v := range structToMap(options) {
+		params.Set(k, v)
+	}
+	if d.options.Cache != nil {
+		return d.translateCached(ctx, text, params)
+	}
+	return d.translateChunked(ctx, text, params)
+}
+
+// translateTexts sends a single translate request for text with the given form parameters.
+func (d *Translator) translateTexts(ctx context.Context, text []string, params url.Values) ([]types.Translation, error) {
+	var response types.Translations
+	form := url.Values{"text": text}
+	for k, v := range params {
+		form[k] = v
+	}
+	err := requests.
+		URL("/translate").
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		BodyForm(form).
+		ToJSON(&response).
+		Fetch(withCharacterCount(ctx, countCharacters(text)))
+	if err != nil {
+		return response.Translations, contextError(ctx, err)
+	}
+	if len(response.Translations) != len(text) {
+		return response.Translations, fmt.Errorf("deepl: got %d translations for %d texts", len(response.Translations), len(text))
+	}
+	d.observeCharacters(ctx, countCharacters(text))
+	return response.Translations, nil
 }
 
-// CreateGlossaryAsync creates a glossary
-func (d *Translator) CreateGlossaryAsync(
+// CreateGlossary creates a glossary. The entries are sent in the tsv format unless
+// WithEntriesFormat selects csv.
+func (d *Translator) CreateGlossary(
+	ctx context.Context,
 	name string,
 	source consts.SourceLang,
 	target consts.TargetLang,
 	glossary GlossaryEntries,
-) tasker.TaskFunc[types.Glossary] {
-	return func(ctx context.Context) (types.Glossary, error) {
-		var response types.Glossary
-		if len(glossary.Entries) == 0 {
-			return response, errors.New("no entries provided")
+	opts ...func(*types.GlossaryOptions) error,
+) (response types.Glossary, err error) {
+	ctx, end := d.observe(ctx, consts.OperationCreateGlossary)
+	defer end(&err)
+	options := types.GlossaryOptions{EntriesFormat: "tsv"}
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return response, err
 		}
-		tsv := glossary.ToTSV()
-		response, err := tasker.Spawn(d.internalCreateGlossary(name, source, target, tsv)).Await()
-		if err != nil {
+	}
+	if err := glossary.Validate(); err != nil {
+		return response, err
+	}
+	if catalog := d.languageCatalog(ctx); catalog != nil {
+		if err := catalog.ValidateGlossary(source, target); err != nil {
 			return response, err
 		}
-		return response, nil
 	}
+	entries := glossary.ToTSV()
+	if options.EntriesFormat == "csv" {
+		entries = glossary.ToCSV()
+	}
+	response, err = d.internalCreateGlossary(ctx, name, source, target, entries, options.EntriesFormat)
+	if err != nil {
+		return response, err
+	}
+	return response, nil
 }
 
 func (d *Translator) internalCreateGlossary(
+	ctx context.Context,
 	name string,
 	source consts.SourceLang,
 	target consts.TargetLang,
 	glossary string,
-) tasker.TaskFunc[types.Glossary] {
-	return func(ctx context.Context) (types.Glossary, error) {
-		var response types.Glossary
-		err := requests.
-			URL("/glossaries").
-			Method("POST").
-			Client(d.HttpClient).
-			Param("name", name).
-			Param("source_lang", string(source)).
-			Param("target_lang", string(target)).
-			Param("entries", glossary).
-			Param("entries_format", "tsv").
-			ToJSON(&response).
-			Fetch(context.Background())
-		if err != nil {
-			return response, err
-		}
-		return response, nil
+	format string,
+) (types.Glossary, error) {
+	var response types.Glossary
+	err := requests.
+		URL("/glossaries").
+		Method("POST").
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		Param("name", name).
+		Param("source_lang", string(source)).
+		Param("target_lang", string(target)).
+		Param("entries", glossary).
+		Param("entries_format", format).
+		ToJSON(&response).
+		Fetch(ctx)
+	if err != nil {
+		return response, contextError(ctx, err)
 	}
+	return response, nil
 }
 
-func (d *Translator) GetGlossariesAsync() tasker.TaskFunc[[]types.Glossary] {
-	return func(ctx context.Context) ([]types.Glossary, error) {
-		var response types.Glossaries
-		err := requests.
-			URL("/glossaries").
-			Client(d.HttpClient).
-			ToJSON(&response).
-			Fetch(context.Background())
-		if err != nil {
-			return response.Glossaries, err
-		}
-		return response.Glossaries, nil
+// GetGlossaries returns all glossaries of the account.
+func (d *Translator) GetGlossaries(ctx context.Context) (_ []types.Glossary, err error) {
+	ctx, end := d.observe(ctx, consts.OperationGetGlossaries)
+	defer end(&err)
+	var response types.Glossaries
+	err = requests.
+		URL("/glossaries").
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		ToJSON(&response).
+		Fetch(ctx)
+	if err != nil {
+		return response.Glossaries, contextError(ctx, err)
 	}
+	return response.Glossaries, nil
 }
 
-// GetGlossaryAsync returns a task that can be awaited to get a glossary.
-func (d *Translator) GetGlossaryDetailsAsync(id string) tasker.TaskFunc[types.Glossary] {
-	return func(ctx context.Context) (types.Glossary, error) {
-		var response types.Glossary
-		err := requests.
-			URL(fmt.Sprintf("/glossaries/%s", id)).
-			Client(d.HttpClient).
-			ToJSON(&response).
-			Fetch(context.Background())
-		if err != nil {
-			return response, err
-		}
-		return response, nil
+// GetGlossaryDetails returns the details of a glossary.
+func (d *Translator) GetGlossaryDetails(ctx context.Context, id string) (_ types.Glossary, err error) {
+	ctx, end := d.observe(ctx, consts.OperationGetGlossary)
+	defer end(&err)
+	var response types.Glossary
+	err = requests.
+		URL(fmt.Sprintf("/glossaries/%s", id)).
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		ToJSON(&response).
+		Fetch(ctx)
+	if err != nil {
+		return response, contextError(ctx, err)
 	}
+	return response, nil
 }
 
-// GetGlossaryEntriesAsync returns a task that can be awaited to get glossaries.
-func (d *Translator) GetGlossaryEntriesAsync(id string) tasker.TaskFunc[GlossaryEntries] {
-	return func(ctx context.Context) (GlossaryEntries, error) {
-		var response string
-		err := requests.
-			URL(fmt.Sprintf("/glossaries/%s/entries", id)).
-			Client(d.HttpClient).
-			ToString(&response).
-			Fetch(context.Background())
-		if err != nil {
-			return GlossaryEntries{}, err
-		}
-		glossaryEntries, err := NewGlossaryEntries(response)
-		if err != nil {
-			return GlossaryEntries{}, err
-		}
-		return *glossaryEntries, nil
+// GetGlossaryEntries returns the entries of a glossary. DeepL returns them in the tsv format only,
+// use ToCSV to convert them.
+func (d *Translator) GetGlossaryEntries(ctx context.Context, id string) (_ GlossaryEntries, err error) {
+	ctx, end := d.observe(ctx, consts.OperationGetGlossaryEntries)
+	defer end(&err)
+	var response string
+	err = requests.
+		URL(fmt.Sprintf("/glossaries/%s/entries", id)).
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		ToString(&response).
+		Fetch(ctx)
+	if err != nil {
+		return GlossaryEntries{}, contextError(ctx, err)
+	}
+	glossaryEntries, err := NewGlossaryEntries(response)
+	if err != nil {
+		return GlossaryEntries{}, err
 	}
+	return *glossaryEntries, nil
 }
 
-// DeleteGlossaryAsync returns a task that can be awaited to delete a glossary.
-func (d *Translator) DeleteGlossaryAsync(id string) tasker.TaskFunc[bool] {
-	return func(ctx context.Context) (bool, error) {
-		err := requests.
-			URL(fmt.Sprintf("/glossaries/%s", id)).
-			Client(d.HttpClient).
-			Delete().
-			Fetch(context.Background())
-		if err != nil {
-			return false, err
-		}
-		return true, nil
+// DeleteGlossary deletes a glossary.
+func (d *Translator) DeleteGlossary(ctx context.Context, id string) (err error) {
+	ctx, end := d.observe(ctx, consts.OperationDeleteGlossary)
+	defer end(&err)
+	err = requests.
+		URL(fmt.Sprintf("/glossaries/%s", id)).
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		Delete().
+		Fetch(ctx)
+	if err != nil {
+		return contextError(ctx, err)
 	}
+	return nil
 }
 
-// GetUsageAsync returns the current usage of the DeepL API.
-func (d *Translator) GetUsageAsync() tasker.TaskFunc[types.Usage] {
-	return func(ctx context.Context) (types.Usage, error) {
-		var response types.Usage
-		err := requests.
-			URL("/usage").
-			Client(d.HttpClient).
-			ToJSON(&response).
-			Fetch(context.Background())
-		if err != nil {
-			return response, err
-		}
-		return response, nil
+// GetUsage returns the current usage of the DeepL API.
+func (d *Translator) GetUsage(ctx context.Context) (_ types.Usage, err error) {
+	ctx, end := d.observe(ctx, consts.OperationGetUsage)
+	defer end(&err)
+	var response types.Usage
+	err = requests.
+		URL("/usage").
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		ToJSON(&response).
+		Fetch(ctx)
+	if err != nil {
+		return response, contextError(ctx, err)
 	}
+	return response, nil
 }
 
-// GetLanguagesAsync returns the supported languages of the DeepL API.
+// GetLanguages returns the supported languages of the DeepL API.
 // The languageType parameter can be either "source" or "target".
-func (d *Translator) GetLanguagesAsync(languageType string) tasker.TaskFunc[[]types.SupportedLanguage] {
-	return func(ctx context.Context) ([]types.SupportedLanguage, error) {
-		var response []types.SupportedLanguage
-		err := requests.
-			URL("/languages").
-			Client(d.HttpClient).
-			Param("type", languageType).
-			ToJSON(&response).
-			Fetch(context.Background())
-		if err != nil {
-			return response, err
-		}
-		return response, nil
+func (d *Translator) GetLanguages(ctx context.Context, languageType string) (_ []types.SupportedLanguage, err error) {
+	ctx, end := d.observe(ctx, consts.OperationGetLanguages)
+	defer end(&err)
+	var response []types.SupportedLanguage
+	err = requests.
+		URL("/languages").
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		Param("type", languageType).
+		ToJSON(&response).
+		Fetch(ctx)
+	if err != nil {
+		return response, contextError(ctx, err)
 	}
+	return response, nil
 }
 
-// GetGlossaryLanguagesAsync returns the supported languages of the DeepL API.
-func (d *Translator) GetGlossaryLanguagesAsync() tasker.TaskFunc[types.GlossaryLanguagePairs] {
-	return func(ctx context.Context) (types.GlossaryLanguagePairs, error) {
-		var response types.GlossaryLanguagePairs
-		err := requests.
-			URL("/glossary-language-pairs").
-			Client(d.HttpClient).
-			ToJSON(&response).
-			Fetch(context.Background())
-		if err != nil {
-			return response, err
-		}
-		return response, nil
+// GetGlossaryLanguages returns the language pairs supported for glossaries.
+func (d *Translator) GetGlossaryLanguages(ctx context.Context) (_ types.GlossaryLanguagePairs, err error) {
+	ctx, end := d.observe(ctx, consts.OperationGetGlossaryLanguages)
+	defer end(&err)
+	var response types.GlossaryLanguagePairs
+	err = requests.
+		URL("/glossary-language-pairs").
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		ToJSON(&response).
+		Fetch(ctx)
+	if err != nil {
+		return response, contextError(ctx, err)
 	}
+	return response, nil
 }
 
 // IsFreeAccountAuthKey returns true if the given auth key is a free account auth key.
@@ -447,10 +504,6 @@ func structToMap(s interface{}) map[string]string {
 	return ret
 }
 
-func checkStatusCode() {
-	//TODO
-}
-
 // constructUserAgentString constructs the user agent string that is sent with each request.
 func constructUserAgentString(sendPlattformInfo bool, appInfo types.AppInfo) string {
 	libraryInfo := "deepl-golang/1.0 "
@@ -2,20 +2,28 @@ package deepl
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
 	"github.com/anthdm/tasker"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/deepltest"
 	"github.com/hsedr/deepl-golang/types"
 )
 
-func MakeTranslator(header map[string]string) (*Translator, error) {
+// MakeTranslator returns a Translator for an in-process deepltest server that is closed with the test.
+func MakeTranslator(t *testing.T, header map[string]string) (*Translator, error) {
+	server := deepltest.NewServer()
+	t.Cleanup(server.Close)
 	key := "auth_key"
 	translator, err := NewTranslator(key,
-		WithServerURL("http://localhost:3000/v2"),
+		WithServerURL(server.ServerURL()),
 		WithUserAgent(true, types.AppInfo{}),
 		WithHeaders(header),
 		WithTimeOut(time.Duration(5)*time.Second),
@@ -27,9 +35,22 @@ func MakeTranslator(header map[string]string) (*Translator, error) {
 	return translator, nil
 }
 
+// makeLocalTranslator returns a Translator talking to a local httptest server serving handler.
+func makeLocalTranslator(t *testing.T, handler http.HandlerFunc, opts ...func(*types.TranslatorOptions) error) *Translator {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	opts = append([]func(*types.TranslatorOptions) error{WithServerURL(server.URL + "/v2"), WithRetries(1)}, opts...)
+	translator, err := NewTranslator("auth_key", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return translator
+}
+
 func TestTranslator_TranslateTextAsync(t *testing.T) {
 	text := []string{"proton beam", "proton beam"}
-	translator, err := MakeTranslator(map[string]string{
+	translator, err := MakeTranslator(t, map[string]string{
 		"mock-server-session":           "TooManyRequests",
 		"mock-server-session-429-count": "4",
 	})
@@ -56,7 +77,7 @@ func TestTranslator_TranslateTextAsync(t *testing.T) {
 }
 
 func TestTranslator_GetUsageAsync(t *testing.T) {
-	translator, err := MakeTranslator(map[string]string{})
+	translator, err := MakeTranslator(t, map[string]string{})
 	if err != nil {
 		t.Errorf(err.Error())
 	}
@@ -71,11 +92,11 @@ func TestTranslator_GetUsageAsync(t *testing.T) {
 }
 
 func TestTranslator_TranslateDocumentAsync(t *testing.T) {
-	translator, err := MakeTranslator(map[string]string{
+	translator, err := MakeTranslator(t, map[string]string{
 		"mock-server-session":                    "TranslateDocumentTranslateTime",
 		"mock-server-session-doc-translate-time": "10000",
 	})
-	file, _ := os.Create("result.txt")
+	file, _ := os.Create(filepath.Join(t.TempDir(), "result.txt"))
 	defer file.Close()
 	input, _ := os.Open("test.txt")
 	defer input.Close()
@@ -87,7 +108,7 @@ func TestTranslator_TranslateDocumentAsync(t *testing.T) {
 }
 
 func TestTranslator_Glossary(t *testing.T) {
-	translator, _ := MakeTranslator(map[string]string{})
+	translator, _ := MakeTranslator(t, map[string]string{})
 	entriesString := "proton\tProtonen\nbeam\tStrahl"
 	entriesMap := map[string]string{
 		"proton": "Protonen",
@@ -149,7 +170,7 @@ func TestTranslator_ConstructUserAgent(t *testing.T) {
 		AppVersion: "1.0",
 	}
 	got := constructUserAgentString(true, appInfo)
-	want := "deepl-golang/1.0 windows go1.20.2 TestApp/1.0"
+	want := fmt.Sprintf("deepl-golang/1.0 %s %s TestApp/1.0", runtime.GOOS, runtime.Version())
 	if got != want {
 		t.Errorf("want: %s, got %s", want, got)
 	}
@@ -161,7 +182,7 @@ func TestGlossaryEntries_ToTSV(t *testing.T) {
 		"beam":   "Strahl",
 	})
 	got := entries.ToTSV()
-	want := "proton\tProtonen\nbeam\tStrahl"
+	want := "beam\tStrahl\nproton\tProtonen"
 	if got != want {
 		t.Errorf("got %s, want %s", got, want)
 	}
@@ -0,0 +1,25 @@
+module github.com/hsedr/deepl-golang/deeplotel
+
+go 1.25.0
+
+require (
+	github.com/hsedr/deepl-golang v0.0.0-20261016232348-b4e35b8aed1b
+	go.opentelemetry.io/otel v1.46.0
+	go.opentelemetry.io/otel/metric v1.46.0
+	go.opentelemetry.io/otel/sdk v1.46.0
+	go.opentelemetry.io/otel/sdk/metric v1.46.0
+	go.opentelemetry.io/otel/trace v1.46.0
+)
+
+require (
+	github.com/anthdm/tasker v0.0.0-20221211183213-9ddc090ec7c4 // indirect
+	github.com/carlmjohnson/requests v0.23.2 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/fatih/structs v1.1.0 // indirect
+	github.com/go-logr/logr v1.4.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
+	golang.org/x/net v0.5.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
@@ -0,0 +1,164 @@
+// Package deeplotel records traces and metrics of a deepl.Translator with OpenTelemetry.
+//
+// It is a separate module, so the OpenTelemetry dependencies are only required by programs using it:
+//
+//	observer, err := deeplotel.NewObserver()
+//	translator, err := deepl.NewTranslator(key, deepl.WithObserver(observer))
+//
+// Every method of the Translator becomes a span with a client span for each attempt of its requests.
+// The metrics are deepl.requests, deepl.request.duration, deepl.retries, deepl.characters.translated
+// and deepl.characters.billed.
+package deeplotel
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the tracer and meter of the Observer.
+const instrumentationName = "github.com/hsedr/deepl-golang/deeplotel"
+
+// Observer implements types.Observer with OpenTelemetry spans and metrics.
+type Observer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+
+	requests   metric.Int64Counter
+	duration   metric.Float64Histogram
+	retries    metric.Int64Counter
+	characters metric.Int64Counter
+	billed     metric.Int64Counter
+}
+
+// Options configure an Observer, the global providers and propagator are used by default.
+type Options struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	Propagator     propagation.TextMapPropagator
+}
+
+// WithTracerProvider sets the provider of the tracer.
+func WithTracerProvider(provider trace.TracerProvider) func(*Options) {
+	return func(o *Options) { o.TracerProvider = provider }
+}
+
+// WithMeterProvider sets the provider of the meter.
+func WithMeterProvider(provider metric.MeterProvider) func(*Options) {
+	return func(o *Options) { o.MeterProvider = provider }
+}
+
+// WithPropagator sets the propagator injecting the trace context into requests.
+func WithPropagator(propagator propagation.TextMapPropagator) func(*Options) {
+	return func(o *Options) { o.Propagator = propagator }
+}
+
+// NewObserver returns an Observer recording with the configured providers.
+func NewObserver(opts ...func(*Options)) (*Observer, error) {
+	options := Options{
+		TracerProvider: otel.GetTracerProvider(),
+		MeterProvider:  otel.GetMeterProvider(),
+		Propagator:     otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	meter := options.MeterProvider.Meter(instrumentationName)
+	o := &Observer{
+		tracer:     options.TracerProvider.Tracer(instrumentationName),
+		propagator: options.Propagator,
+	}
+	var err error
+	if o.requests, err = meter.Int64Counter("deepl.requests",
+		metric.WithDescription("Number of request attempts sent to DeepL"),
+		metric.WithUnit("{request}")); err != nil {
+		return nil, err
+	}
+	if o.duration, err = meter.Float64Histogram("deepl.request.duration",
+		metric.WithDescription("Duration of request attempts until the response headers are received"),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if o.retries, err = meter.Int64Counter("deepl.retries",
+		metric.WithDescription("Number of retried request attempts"),
+		metric.WithUnit("{request}")); err != nil {
+		return nil, err
+	}
+	if o.characters, err = meter.Int64Counter("deepl.characters.translated",
+		metric.WithDescription("Characters of successfully translated texts"),
+		metric.WithUnit("{character}")); err != nil {
+		return nil, err
+	}
+	if o.billed, err = meter.Int64Counter("deepl.characters.billed",
+		metric.WithDescription("Characters billed for translated documents"),
+		metric.WithUnit("{character}")); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// StartOperation starts a span named after the operation, e.g. deepl.translate_text.
+func (o *Observer) StartOperation(ctx context.Context, op consts.Operation) (context.Context, func(error)) {
+	ctx, span := o.tracer.Start(ctx, "deepl."+string(op), trace.WithAttributes(attribute.String("deepl.operation", string(op))))
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// StartRequest starts a client span for an attempt of a request and injects the trace context into its headers.
+func (o *Observer) StartRequest(ctx context.Context, req *http.Request, attempt int) (context.Context, func(*http.Response, error)) {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("url.path", req.URL.Path),
+		attribute.String("server.address", req.URL.Hostname()),
+	}
+	ctx, span := o.tracer.Start(ctx, req.Method+" "+req.URL.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(attribute.Int("http.request.resend_count", attempt-1)),
+	)
+	o.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	if attempt > 1 {
+		o.retries.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+	start := time.Now()
+	return ctx, func(res *http.Response, err error) {
+		if res != nil {
+			attrs = append(attrs, attribute.Int("http.response.status_code", res.StatusCode))
+			span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+			if res.StatusCode >= 400 {
+				span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+			}
+		}
+		if err != nil {
+			attrs = append(attrs, attribute.String("error.type", "transport"))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		o.requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+		o.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+		span.End()
+	}
+}
+
+// TranslatedCharacters counts translated characters.
+func (o *Observer) TranslatedCharacters(ctx context.Context, n int) {
+	o.characters.Add(ctx, int64(n))
+}
+
+// BilledCharacters counts characters billed for documents.
+func (o *Observer) BilledCharacters(ctx context.Context, n int) {
+	o.billed.Add(ctx, int64(n))
+}
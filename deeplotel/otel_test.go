@@ -0,0 +1,77 @@
+package deeplotel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	deepl "github.com/hsedr/deepl-golang"
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/deepltest"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestObserver(t *testing.T) {
+	server := deepltest.NewServer(deepltest.WithTooManyRequests(1))
+	defer server.Close()
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	observer, err := NewObserver(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	translator, err := deepl.NewTranslator("auth_key",
+		deepl.WithServerURL(server.ServerURL()),
+		deepl.WithRetryBackoff(time.Millisecond, time.Millisecond, 1, 0),
+		deepl.WithObserver(observer),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err := translator.TranslateText(ctx, []string{"proton beam"}, consts.SourceLangEnglish, consts.TargetLangGerman); err != nil {
+		t.Fatal(err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 3 {
+		t.Fatalf("got %d spans, want an operation and two request spans", len(ended))
+	}
+	operation := ended[2]
+	if operation.Name() != "deepl.translate_text" {
+		t.Errorf("got operation span %q", operation.Name())
+	}
+	for _, span := range ended[:2] {
+		if span.SpanKind() != trace.SpanKindClient || span.Parent().SpanID() != operation.SpanContext().SpanID() {
+			t.Errorf("request span %q is not a client child of the operation", span.Name())
+		}
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &metrics); err != nil {
+		t.Fatal(err)
+	}
+	sums := map[string]int64{}
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, point := range sum.DataPoints {
+					sums[m.Name] += point.Value
+				}
+			}
+		}
+	}
+	want := map[string]int64{"deepl.requests": 2, "deepl.retries": 1, "deepl.characters.translated": 11}
+	for name, value := range want {
+		if sums[name] != value {
+			t.Errorf("got %s = %d, want %d", name, sums[name], value)
+		}
+	}
+}
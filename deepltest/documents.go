@@ -0,0 +1,131 @@
+package deepltest
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// document is an uploaded document and its translation.
+type document struct {
+	id         string
+	key        string
+	content    string
+	translated string
+	uploaded   time.Time
+	queueTime  time.Duration
+	translate  time.Duration
+	failure    bool
+	billed     int
+}
+
+// status returns the status of the document translation at now.
+func (d *document) status(now time.Time) (string, int) {
+	elapsed := now.Sub(d.uploaded)
+	switch {
+	case elapsed < d.queueTime:
+		return "queued", 0
+	case elapsed < d.queueTime+d.translate:
+		return "translating", formatSeconds(d.queueTime + d.translate - elapsed)
+	case d.failure:
+		return "error", 0
+	}
+	return "done", 0
+}
+
+func (s *Server) handleDocument(w http.ResponseWriter, r *http.Request, sess *session, parts []string) {
+	if len(parts) == 0 {
+		s.uploadDocument(w, r, sess)
+		return
+	}
+	doc, ok := s.documents[parts[0]]
+	if !ok || doc.key != r.Form.Get("document_key") {
+		writeError(w, http.StatusNotFound, "Document not found")
+		return
+	}
+	status, secondsRemaining := doc.status(time.Now())
+	switch {
+	case len(parts) == 1:
+		response := map[string]any{"document_id": doc.id, "status": status}
+		switch status {
+		case "translating":
+			response["seconds_remaining"] = secondsRemaining
+		case "done":
+			response["billed_characters"] = doc.billed
+		case "error":
+			response["error_message"] = "Translation error triggered"
+		}
+		writeJSON(w, http.StatusOK, response)
+	case len(parts) == 2 && parts[1] == "result":
+		if status != "done" {
+			writeError(w, http.StatusServiceUnavailable, "Document not ready")
+			return
+		}
+		delete(s.documents, doc.id)
+		w.Header().Set("Content-Type", "application/octet-stream")
+		io.WriteString(w, doc.translated)
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) uploadDocument(w http.ResponseWriter, r *http.Request, sess *session) {
+	if r.MultipartForm == nil {
+		errorf(w, "Invalid file data.")
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		errorf(w, "Invalid file data: %v", err)
+		return
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		errorf(w, "Invalid file data: %v", err)
+		return
+	}
+	sourceLang := strings.ToUpper(r.FormValue("source_lang"))
+	targetLang := strings.ToUpper(r.FormValue("target_lang"))
+	if !isTargetLanguage(targetLang) {
+		errorf(w, "Value for 'target_lang' not supported.")
+		return
+	}
+	var entries map[string]string
+	if id := r.FormValue("glossary_id"); id != "" {
+		var ok bool
+		if entries, ok = s.glossaryEntries(id, sourceLang, targetLang); !ok {
+			writeError(w, http.StatusNotFound, "Glossary not found")
+			return
+		}
+	}
+	billed := utf8.RuneCount(content)
+	if sess.characterCount+billed > sess.characterLimit {
+		writeError(w, 456, "Quota exceeded")
+		return
+	}
+	sess.characterCount += billed
+	sess.documentCount++
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		detected := sourceLang
+		if detected == "" {
+			detected = detectLanguage(line)
+		}
+		lines[i] = applyGlossary(s.options.Translate(line, detected, targetLang), entries)
+	}
+	doc := &document{
+		id:         newID(),
+		key:        newID() + newID(),
+		content:    string(content),
+		translated: strings.Join(lines, "\n"),
+		uploaded:   time.Now(),
+		queueTime:  sess.queueTime,
+		translate:  sess.translateTime,
+		failure:    sess.documentFailure,
+		billed:     billed,
+	}
+	s.documents[doc.id] = doc
+	writeJSON(w, http.StatusOK, map[string]string{"document_id": doc.id, "document_key": doc.key})
+}
@@ -0,0 +1,224 @@
+package deepltest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// glossary is a stored glossary. Glossaries created with the v2 API have a single dictionary,
+// multilingual glossaries created with the v3 API may have several.
+type glossary struct {
+	id           string
+	name         string
+	created      time.Time
+	ready        time.Time
+	dictionaries []*glossaryDictionary
+}
+
+// glossaryDictionary holds the entries of a glossary for one language pair.
+type glossaryDictionary struct {
+	sourceLang string
+	targetLang string
+	entries    map[string]string
+}
+
+func (g *glossary) json() map[string]any {
+	d := g.dictionaries[0]
+	return map[string]any{
+		"glossary_id":   g.id,
+		"name":          g.name,
+		"ready":         !time.Now().Before(g.ready),
+		"source_lang":   d.sourceLang,
+		"target_lang":   d.targetLang,
+		"creation_time": g.created.Format(time.RFC3339Nano),
+		"entry_count":   len(d.entries),
+	}
+}
+
+// dictionary returns the dictionary of the language pair, nil if there is none.
+func (g *glossary) dictionary(sourceLang, targetLang string) *glossaryDictionary {
+	for _, d := range g.dictionaries {
+		if d.sourceLang == sourceLang && d.targetLang == targetLang {
+			return d
+		}
+	}
+	return nil
+}
+
+// glossaryEntries returns the entries of glossary id used to translate from sourceLang into targetLang.
+// An empty sourceLang matches the first dictionary of the target language.
+func (s *Server) glossaryEntries(id, sourceLang, targetLang string) (map[string]string, bool) {
+	g, ok := s.glossaries[id]
+	if !ok {
+		return nil, false
+	}
+	sourceLang = strings.ToLower(sourceLang)
+	targetLang = strings.ToLower(baseLanguage(targetLang))
+	for _, d := range g.dictionaries {
+		if (sourceLang == "" || d.sourceLang == sourceLang) && d.targetLang == targetLang {
+			return d.entries, true
+		}
+	}
+	return nil, true
+}
+
+// sortedGlossaries returns all glossaries sorted by creation time.
+func (s *Server) sortedGlossaries() []*glossary {
+	glossaries := make([]*glossary, 0, len(s.glossaries))
+	for _, g := range s.glossaries {
+		glossaries = append(glossaries, g)
+	}
+	sort.Slice(glossaries, func(i, j int) bool { return glossaries[i].created.Before(glossaries[j].created) })
+	return glossaries
+}
+
+func (s *Server) handleGlossaries(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] == "" {
+		switch r.Method {
+		case http.MethodPost:
+			s.createGlossary(w, r)
+		case http.MethodGet:
+			// multilingual glossaries are only listed by the v3 API
+			list := []map[string]any{}
+			for _, g := range s.sortedGlossaries() {
+				if len(g.dictionaries) == 1 {
+					list = append(list, g.json())
+				}
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"glossaries": list})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+	g, ok := s.glossaries[parts[0]]
+	if !ok || len(g.dictionaries) != 1 {
+		writeError(w, http.StatusNotFound, "Glossary not found")
+		return
+	}
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, g.json())
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		delete(s.glossaries, g.id)
+		w.WriteHeader(http.StatusNoContent)
+	case len(parts) == 2 && parts[1] == "entries" && r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "text/tab-separated-values")
+		w.Write([]byte(formatTSV(g.dictionaries[0].entries)))
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) createGlossary(w http.ResponseWriter, r *http.Request) {
+	name := r.Form.Get("name")
+	sourceLang := strings.ToLower(r.Form.Get("source_lang"))
+	targetLang := strings.ToLower(r.Form.Get("target_lang"))
+	if name == "" {
+		errorf(w, "Parameter 'name' not specified.")
+		return
+	}
+	if !isGlossaryPair(sourceLang, targetLang) {
+		errorf(w, "Unsupported glossary source and target language pair.")
+		return
+	}
+	entries, err := parseEntries(r.Form.Get("entries"), r.Form.Get("entries_format"))
+	if err != nil {
+		errorf(w, "Invalid glossary entries provided: %v", err)
+		return
+	}
+	g := &glossary{
+		id:      strings.ToLower(newID()),
+		name:    name,
+		created: time.Now().UTC(),
+		ready:   time.Now().Add(s.options.GlossaryReadyTime),
+		dictionaries: []*glossaryDictionary{
+			{sourceLang: sourceLang, targetLang: strings.ToLower(baseLanguage(targetLang)), entries: entries},
+		},
+	}
+	s.glossaries[g.id] = g
+	writeJSON(w, http.StatusCreated, g.json())
+}
+
+// isGlossaryPair reports whether glossaries support the language pair.
+func isGlossaryPair(sourceLang, targetLang string) bool {
+	return isGlossaryLanguage(sourceLang) && isGlossaryLanguage(baseLanguage(targetLang)) &&
+		!strings.EqualFold(sourceLang, baseLanguage(targetLang))
+}
+
+func isGlossaryLanguage(code string) bool {
+	for _, l := range glossaryLanguages {
+		if strings.EqualFold(l, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEntries parses glossary entries in the tsv or csv format.
+func parseEntries(data, format string) (map[string]string, error) {
+	entries := map[string]string{}
+	switch format {
+	case "", "tsv":
+		for i, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			source, target, ok := strings.Cut(line, "\t")
+			if !ok || strings.Contains(target, "\t") {
+				return nil, fmt.Errorf("line %d: expected two tab separated terms", i+1)
+			}
+			if err := addEntry(entries, source, target); err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+		}
+	case "csv":
+		records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		for i, record := range records {
+			if len(record) < 2 {
+				return nil, fmt.Errorf("line %d: expected two terms", i+1)
+			}
+			if err := addEntry(entries, record[0], record[1]); err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported entries format %q", format)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no entries")
+	}
+	return entries, nil
+}
+
+func addEntry(entries map[string]string, source, target string) error {
+	if source == "" || target == "" {
+		return fmt.Errorf("empty term")
+	}
+	if _, ok := entries[source]; ok {
+		return fmt.Errorf("duplicate source term %q", source)
+	}
+	entries[source] = target
+	return nil
+}
+
+// formatTSV returns the entries sorted by source term in the tsv format.
+func formatTSV(entries map[string]string) string {
+	sources := make([]string, 0, len(entries))
+	for source := range entries {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	lines := make([]string, len(sources))
+	for i, source := range sources {
+		lines[i] = source + "\t" + entries[source]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,160 @@
+package deepltest
+
+import "strings"
+
+type language struct {
+	code      string
+	name      string
+	formality bool
+}
+
+var sourceLanguages = []language{
+	{"BG", "Bulgarian", false},
+	{"CS", "Czech", false},
+	{"DA", "Danish", false},
+	{"DE", "German", false},
+	{"EL", "Greek", false},
+	{"EN", "English", false},
+	{"ES", "Spanish", false},
+	{"ET", "Estonian", false},
+	{"FI", "Finnish", false},
+	{"FR", "French", false},
+	{"HU", "Hungarian", false},
+	{"ID", "Indonesian", false},
+	{"IT", "Italian", false},
+	{"JA", "Japanese", false},
+	{"KO", "Korean", false},
+	{"LT", "Lithuanian", false},
+	{"LV", "Latvian", false},
+	{"NB", "Norwegian (Bokmål)", false},
+	{"NL", "Dutch", false},
+	{"PL", "Polish", false},
+	{"PT", "Portuguese", false},
+	{"RO", "Romanian", false},
+	{"RU", "Russian", false},
+	{"SK", "Slovak", false},
+	{"SL", "Slovenian", false},
+	{"SV", "Swedish", false},
+	{"TR", "Turkish", false},
+	{"UK", "Ukrainian", false},
+	{"ZH", "Chinese", false},
+}
+
+var targetLanguages = []language{
+	{"BG", "Bulgarian", false},
+	{"CS", "Czech", false},
+	{"DA", "Danish", false},
+	{"DE", "German", true},
+	{"EL", "Greek", false},
+	{"EN-GB", "English (British)", false},
+	{"EN-US", "English (American)", false},
+	{"ES", "Spanish", true},
+	{"ET", "Estonian", false},
+	{"FI", "Finnish", false},
+	{"FR", "French", true},
+	{"HU", "Hungarian", false},
+	{"ID", "Indonesian", false},
+	{"IT", "Italian", true},
+	{"JA", "Japanese", true},
+	{"KO", "Korean", false},
+	{"LT", "Lithuanian", false},
+	{"LV", "Latvian", false},
+	{"NB", "Norwegian (Bokmål)", false},
+	{"NL", "Dutch", true},
+	{"PL", "Polish", true},
+	{"PT-BR", "Portuguese (Brazilian)", true},
+	{"PT-PT", "Portuguese (European)", true},
+	{"RO", "Romanian", false},
+	{"RU", "Russian", true},
+	{"SK", "Slovak", false},
+	{"SL", "Slovenian", false},
+	{"SV", "Swedish", false},
+	{"TR", "Turkish", false},
+	{"UK", "Ukrainian", false},
+	{"ZH", "Chinese (simplified)", false},
+}
+
+// glossaryLanguages are the languages glossaries can be created for, in every combination.
+var glossaryLanguages = []string{"DE", "EN", "ES", "FR", "IT", "JA", "NL", "PL", "PT", "RU", "ZH"}
+
+// dictionary holds the texts known to the default translate function, by base language.
+var dictionary = map[string]map[string]string{
+	"EN": {"proton beam": "proton beam"},
+	"DE": {"proton beam": "Protonenstrahl"},
+	"FR": {"proton beam": "faisceau de protons"},
+	"ES": {"proton beam": "haz de protones"},
+	"IT": {"proton beam": "fascio di protoni"},
+	"NL": {"proton beam": "protonenbundel"},
+}
+
+func isSourceLanguage(code string) bool {
+	for _, l := range sourceLanguages {
+		if l.code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func isTargetLanguage(code string) bool {
+	for _, l := range targetLanguages {
+		if l.code == code {
+			return true
+		}
+	}
+	return code == "EN" || code == "PT"
+}
+
+func glossaryLanguagePairs() []map[string]string {
+	var pairs []map[string]string
+	for _, source := range glossaryLanguages {
+		for _, target := range glossaryLanguages {
+			if source != target {
+				pairs = append(pairs, map[string]string{"source_lang": strings.ToLower(source), "target_lang": strings.ToLower(target)})
+			}
+		}
+	}
+	return pairs
+}
+
+// baseLanguage strips the variant of a language code, e.g. EN-US becomes EN.
+func baseLanguage(code string) string {
+	base, _, _ := strings.Cut(strings.ToUpper(code), "-")
+	return base
+}
+
+// dictionaryTranslate translates texts known to the dictionary and returns all others unchanged.
+func dictionaryTranslate(text, sourceLang, targetLang string) string {
+	key := strings.ToLower(text)
+	for _, phrases := range dictionary {
+		for english, translated := range phrases {
+			if strings.EqualFold(translated, text) {
+				key = english
+			}
+		}
+	}
+	if translated, ok := dictionary[baseLanguage(targetLang)][key]; ok {
+		return translated
+	}
+	return text
+}
+
+// detectLanguage returns the language of a dictionary text, defaulting to EN.
+func detectLanguage(text string) string {
+	for lang, phrases := range dictionary {
+		for _, translated := range phrases {
+			if lang != "EN" && strings.EqualFold(translated, text) {
+				return lang
+			}
+		}
+	}
+	return "EN"
+}
+
+// applyGlossary replaces all occurrences of glossary source terms in text.
+func applyGlossary(text string, entries map[string]string) string {
+	for source, target := range entries {
+		text = strings.ReplaceAll(text, source, target)
+	}
+	return text
+}
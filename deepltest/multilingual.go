@@ -0,0 +1,208 @@
+package deepltest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// dictionaryRequest is a dictionary in the request body of the v3 glossary API.
+type dictionaryRequest struct {
+	SourceLang    string `json:"source_lang"`
+	TargetLang    string `json:"target_lang"`
+	Entries       string `json:"entries"`
+	EntriesFormat string `json:"entries_format"`
+}
+
+// parse validates the dictionary and parses its entries.
+func (d dictionaryRequest) parse() (*glossaryDictionary, string) {
+	sourceLang := strings.ToLower(d.SourceLang)
+	targetLang := strings.ToLower(baseLanguage(d.TargetLang))
+	if !isGlossaryPair(sourceLang, targetLang) {
+		return nil, "Unsupported glossary source and target language pair."
+	}
+	entries, err := parseEntries(d.Entries, d.EntriesFormat)
+	if err != nil {
+		return nil, "Invalid glossary entries provided: " + err.Error()
+	}
+	return &glossaryDictionary{sourceLang: sourceLang, targetLang: targetLang, entries: entries}, ""
+}
+
+func (d *glossaryDictionary) json() map[string]any {
+	return map[string]any{
+		"source_lang": d.sourceLang,
+		"target_lang": d.targetLang,
+		"entry_count": len(d.entries),
+	}
+}
+
+// multilingualJSON returns the glossary as returned by the v3 API.
+func (g *glossary) multilingualJSON() map[string]any {
+	dictionaries := make([]map[string]any, len(g.dictionaries))
+	for i, d := range g.dictionaries {
+		dictionaries[i] = d.json()
+	}
+	return map[string]any{
+		"glossary_id":   g.id,
+		"name":          g.name,
+		"dictionaries":  dictionaries,
+		"creation_time": g.created.Format(time.RFC3339Nano),
+	}
+}
+
+// handleMultilingualGlossaries serves the v3 glossary API below /v3/glossaries.
+func (s *Server) handleMultilingualGlossaries(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] == "" {
+		switch r.Method {
+		case http.MethodPost:
+			s.createMultilingualGlossary(w, r)
+		case http.MethodGet:
+			list := []map[string]any{}
+			for _, g := range s.sortedGlossaries() {
+				list = append(list, g.multilingualJSON())
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"glossaries": list})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+	g, ok := s.glossaries[parts[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Glossary not found")
+		return
+	}
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, g.multilingualJSON())
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		delete(s.glossaries, g.id)
+		w.WriteHeader(http.StatusNoContent)
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		s.updateMultilingualGlossary(w, r, g)
+	case len(parts) == 2 && parts[1] == "dictionaries" && r.Method == http.MethodPut:
+		s.replaceDictionary(w, r, g)
+	case len(parts) == 2 && parts[1] == "dictionaries" && r.Method == http.MethodDelete:
+		d := g.dictionary(strings.ToLower(r.Form.Get("source_lang")), strings.ToLower(baseLanguage(r.Form.Get("target_lang"))))
+		if d == nil {
+			writeError(w, http.StatusNotFound, "Dictionary not found")
+			return
+		}
+		if len(g.dictionaries) == 1 {
+			errorf(w, "A glossary needs at least one dictionary.")
+			return
+		}
+		for i := range g.dictionaries {
+			if g.dictionaries[i] == d {
+				g.dictionaries = append(g.dictionaries[:i], g.dictionaries[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(parts) == 2 && parts[1] == "entries" && r.Method == http.MethodGet:
+		d := g.dictionary(strings.ToLower(r.Form.Get("source_lang")), strings.ToLower(baseLanguage(r.Form.Get("target_lang"))))
+		if d == nil {
+			writeError(w, http.StatusNotFound, "Dictionary not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"dictionaries": []map[string]string{{
+			"source_lang":    d.sourceLang,
+			"target_lang":    d.targetLang,
+			"entries":        formatTSV(d.entries),
+			"entries_format": "tsv",
+		}}})
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) createMultilingualGlossary(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name         string              `json:"name"`
+		Dictionaries []dictionaryRequest `json:"dictionaries"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		errorf(w, "Invalid request body: %v", err)
+		return
+	}
+	if body.Name == "" {
+		errorf(w, "Parameter 'name' not specified.")
+		return
+	}
+	if len(body.Dictionaries) == 0 {
+		errorf(w, "Parameter 'dictionaries' not specified.")
+		return
+	}
+	g := &glossary{id: strings.ToLower(newID()), name: body.Name, created: time.Now().UTC()}
+	for _, request := range body.Dictionaries {
+		d, message := request.parse()
+		if message != "" {
+			errorf(w, "%s", message)
+			return
+		}
+		if g.dictionary(d.sourceLang, d.targetLang) != nil {
+			errorf(w, "Duplicate dictionary for %s-%s.", d.sourceLang, d.targetLang)
+			return
+		}
+		g.dictionaries = append(g.dictionaries, d)
+	}
+	s.glossaries[g.id] = g
+	writeJSON(w, http.StatusCreated, g.multilingualJSON())
+}
+
+// updateMultilingualGlossary renames the glossary and merges the entries of the dictionaries into it.
+func (s *Server) updateMultilingualGlossary(w http.ResponseWriter, r *http.Request, g *glossary) {
+	var body struct {
+		Name         string              `json:"name"`
+		Dictionaries []dictionaryRequest `json:"dictionaries"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		errorf(w, "Invalid request body: %v", err)
+		return
+	}
+	var updates []*glossaryDictionary
+	for _, request := range body.Dictionaries {
+		d, message := request.parse()
+		if message != "" {
+			errorf(w, "%s", message)
+			return
+		}
+		updates = append(updates, d)
+	}
+	if body.Name != "" {
+		g.name = body.Name
+	}
+	for _, update := range updates {
+		d := g.dictionary(update.sourceLang, update.targetLang)
+		if d == nil {
+			g.dictionaries = append(g.dictionaries, update)
+			continue
+		}
+		for source, target := range update.entries {
+			d.entries[source] = target
+		}
+	}
+	writeJSON(w, http.StatusOK, g.multilingualJSON())
+}
+
+// replaceDictionary replaces the entries of a dictionary, creating it if it does not exist.
+func (s *Server) replaceDictionary(w http.ResponseWriter, r *http.Request, g *glossary) {
+	var request dictionaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		errorf(w, "Invalid request body: %v", err)
+		return
+	}
+	update, message := request.parse()
+	if message != "" {
+		errorf(w, "%s", message)
+		return
+	}
+	if d := g.dictionary(update.sourceLang, update.targetLang); d != nil {
+		d.entries = update.entries
+		writeJSON(w, http.StatusOK, d.json())
+		return
+	}
+	g.dictionaries = append(g.dictionaries, update)
+	writeJSON(w, http.StatusCreated, update.json())
+}
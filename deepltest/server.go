@@ -0,0 +1,327 @@
+// Package deepltest provides an in-process fake of the DeepL API for tests.
+//
+// The Server implements /translate, /document, /glossaries, /glossary-language-pairs,
+// /usage and /languages, and the multilingual glossaries of /v3/glossaries. Its behaviour can be scripted with options or per session
+// with the same mock-server-session headers the DeepL mock server understands.
+package deepltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Session headers understood by the Server, see https://github.com/DeepLcom/deepl-mock.
+const (
+	HeaderSession                 = "mock-server-session"
+	HeaderSession429Count         = "mock-server-session-429-count"
+	HeaderSessionCharacterLimit   = "mock-server-session-init-character-limit"
+	HeaderSessionDocFailure       = "mock-server-session-doc-failure"
+	HeaderSessionDocQueueTime     = "mock-server-session-doc-queue-time"
+	HeaderSessionDocTranslateTime = "mock-server-session-doc-translate-time"
+)
+
+// Options configure the default behaviour of a Server. Sessions started with
+// mock-server-session headers override them.
+type Options struct {
+	// AuthKey, if set, is required in the Authorization header, otherwise 403 is returned.
+	AuthKey string
+
+	// TooManyRequests is the number of requests answered with 429 before requests succeed.
+	TooManyRequests int
+
+	// CharacterLimit is the number of characters that can be translated before 456 is returned.
+	CharacterLimit int
+
+	// DocumentQueueTime and DocumentTranslateTime control how long documents
+	// stay in the queued and translating states.
+	DocumentQueueTime     time.Duration
+	DocumentTranslateTime time.Duration
+
+	// DocumentFailure makes every document translation end with an error status.
+	DocumentFailure bool
+
+	// GlossaryReadyTime is how long created glossaries are reported as not ready.
+	GlossaryReadyTime time.Duration
+
+	// Translate translates a single text. Defaults to a small dictionary that
+	// returns unknown texts unchanged.
+	Translate func(text, sourceLang, targetLang string) string
+}
+
+// Server is a fake DeepL API server. The API is served below /v2, use ServerURL as server url of a Translator.
+type Server struct {
+	*httptest.Server
+
+	options Options
+
+	mu         sync.Mutex
+	sessions   map[string]*session
+	documents  map[string]*document
+	glossaries map[string]*glossary
+}
+
+// session holds the state of one mock-server-session.
+type session struct {
+	tooManyRequests int
+	characterCount  int
+	characterLimit  int
+	documentCount   int
+	queueTime       time.Duration
+	translateTime   time.Duration
+	documentFailure bool
+}
+
+// NewServer starts a Server. It must be closed with Close.
+func NewServer(opts ...func(*Options)) *Server {
+	s := &Server{
+		options:    Options{CharacterLimit: 20000000},
+		sessions:   map[string]*session{},
+		documents:  map[string]*document{},
+		glossaries: map[string]*glossary{},
+	}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	if s.options.Translate == nil {
+		s.options.Translate = dictionaryTranslate
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// WithAuthKey requires authKey in the Authorization header of every request.
+func WithAuthKey(authKey string) func(*Options) {
+	return func(o *Options) { o.AuthKey = authKey }
+}
+
+// WithTooManyRequests answers the first n requests with 429.
+func WithTooManyRequests(n int) func(*Options) {
+	return func(o *Options) { o.TooManyRequests = n }
+}
+
+// WithCharacterLimit sets the number of characters that can be translated before 456 is returned.
+func WithCharacterLimit(limit int) func(*Options) {
+	return func(o *Options) { o.CharacterLimit = limit }
+}
+
+// WithDocumentTimes sets how long documents stay queued and translating.
+func WithDocumentTimes(queue, translate time.Duration) func(*Options) {
+	return func(o *Options) {
+		o.DocumentQueueTime = queue
+		o.DocumentTranslateTime = translate
+	}
+}
+
+// WithDocumentFailure makes every document translation fail.
+func WithDocumentFailure() func(*Options) {
+	return func(o *Options) { o.DocumentFailure = true }
+}
+
+// WithGlossaryReadyTime reports created glossaries as not ready for d.
+func WithGlossaryReadyTime(d time.Duration) func(*Options) {
+	return func(o *Options) { o.GlossaryReadyTime = d }
+}
+
+// WithTranslate sets the function used to translate texts.
+func WithTranslate(translate func(text, sourceLang, targetLang string) string) func(*Options) {
+	return func(o *Options) { o.Translate = translate }
+}
+
+// ServerURL returns the url to use as server url of a Translator, including the /v2 prefix.
+func (s *Server) ServerURL() string {
+	return s.URL + "/v2"
+}
+
+// CharacterCount returns the number of characters translated without session header.
+func (s *Server) CharacterCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.session(&http.Request{Header: http.Header{}}).characterCount
+}
+
+// session returns the session of the request, creating it from the options and headers on first use.
+// It must be called with s.mu held.
+func (s *Server) session(r *http.Request) *session {
+	id := r.Header.Get(HeaderSession)
+	if sess, ok := s.sessions[id]; ok {
+		return sess
+	}
+	sess := &session{
+		tooManyRequests: s.options.TooManyRequests,
+		characterLimit:  s.options.CharacterLimit,
+		queueTime:       s.options.DocumentQueueTime,
+		translateTime:   s.options.DocumentTranslateTime,
+		documentFailure: s.options.DocumentFailure,
+	}
+	if n, err := strconv.Atoi(r.Header.Get(HeaderSession429Count)); err == nil {
+		sess.tooManyRequests = n
+	}
+	if n, err := strconv.Atoi(r.Header.Get(HeaderSessionCharacterLimit)); err == nil {
+		sess.characterLimit = n
+	}
+	if n, err := strconv.Atoi(r.Header.Get(HeaderSessionDocQueueTime)); err == nil {
+		sess.queueTime = time.Duration(n) * time.Millisecond
+	}
+	if n, err := strconv.Atoi(r.Header.Get(HeaderSessionDocTranslateTime)); err == nil {
+		sess.translateTime = time.Duration(n) * time.Millisecond
+	}
+	if r.Header.Get(HeaderSessionDocFailure) != "" {
+		sess.documentFailure = true
+	}
+	s.sessions[id] = sess
+	return sess
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.options.AuthKey != "" && r.Header.Get("Authorization") != "DeepL-Auth-Key "+s.options.AuthKey {
+		writeError(w, http.StatusForbidden, "Wrong auth key")
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/v2")
+	var err error
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		err = r.ParseMultipartForm(32 << 20)
+	} else {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess := s.session(r)
+	if sess.tooManyRequests > 0 {
+		sess.tooManyRequests--
+		writeError(w, http.StatusTooManyRequests, "Too many requests")
+		return
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case path == "/translate":
+		s.handleTranslate(w, r, sess)
+	case path == "/usage":
+		writeJSON(w, http.StatusOK, map[string]int{
+			"character_count": sess.characterCount,
+			"character_limit": sess.characterLimit,
+			"document_count":  sess.documentCount,
+			"document_limit":  10000,
+		})
+	case path == "/languages":
+		s.handleLanguages(w, r)
+	case path == "/glossary-language-pairs":
+		writeJSON(w, http.StatusOK, map[string]any{"supported_languages": glossaryLanguagePairs()})
+	case parts[0] == "document":
+		s.handleDocument(w, r, sess, parts[1:])
+	case parts[0] == "glossaries":
+		s.handleGlossaries(w, r, parts[1:])
+	case parts[0] == "v3" && len(parts) > 1 && parts[1] == "glossaries":
+		s.handleMultilingualGlossaries(w, r, parts[2:])
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) handleTranslate(w http.ResponseWriter, r *http.Request, sess *session) {
+	texts := r.Form["text"]
+	sourceLang := strings.ToUpper(r.Form.Get("source_lang"))
+	targetLang := strings.ToUpper(r.Form.Get("target_lang"))
+	if len(texts) == 0 {
+		writeError(w, http.StatusBadRequest, "Parameter 'text' not specified.")
+		return
+	}
+	if !isTargetLanguage(targetLang) {
+		writeError(w, http.StatusBadRequest, "Value for 'target_lang' not supported.")
+		return
+	}
+	if sourceLang != "" && !isSourceLanguage(sourceLang) {
+		writeError(w, http.StatusBadRequest, "Value for 'source_lang' not supported.")
+		return
+	}
+	var entries map[string]string
+	if id := r.Form.Get("glossary_id"); id != "" {
+		var ok bool
+		if entries, ok = s.glossaryEntries(id, sourceLang, targetLang); !ok {
+			writeError(w, http.StatusNotFound, "Glossary not found")
+			return
+		}
+	}
+	characters := 0
+	for _, text := range texts {
+		characters += utf8.RuneCountInString(text)
+	}
+	if sess.characterCount+characters > sess.characterLimit {
+		writeError(w, 456, "Quota exceeded")
+		return
+	}
+	sess.characterCount += characters
+	type translation struct {
+		DetectedSourceLanguage string `json:"detected_source_language"`
+		Text                   string `json:"text"`
+	}
+	translations := make([]translation, len(texts))
+	for i, text := range texts {
+		detected := sourceLang
+		if detected == "" {
+			detected = detectLanguage(text)
+		}
+		translations[i] = translation{
+			DetectedSourceLanguage: detected,
+			Text:                   applyGlossary(s.options.Translate(text, detected, targetLang), entries),
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"translations": translations})
+}
+
+func (s *Server) handleLanguages(w http.ResponseWriter, r *http.Request) {
+	type language struct {
+		Language          string `json:"language"`
+		Name              string `json:"name"`
+		SupportsFormality bool   `json:"supports_formality"`
+	}
+	var languages []language
+	if r.Form.Get("type") == "target" {
+		for _, l := range targetLanguages {
+			languages = append(languages, language{l.code, l.name, l.formality})
+		}
+	} else {
+		for _, l := range sourceLanguages {
+			languages = append(languages, language{Language: l.code, Name: l.name})
+		}
+	}
+	writeJSON(w, http.StatusOK, languages)
+}
+
+// newID returns a random id in the format used by DeepL.
+func newID() string {
+	return strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", ""))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+// formatSeconds returns the whole seconds of d, rounded up.
+func formatSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
+
+// errorf is a shorthand to write a 400 error with a formatted message.
+func errorf(w http.ResponseWriter, format string, a ...any) {
+	writeError(w, http.StatusBadRequest, fmt.Sprintf(format, a...))
+}
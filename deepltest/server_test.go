@@ -0,0 +1,64 @@
+package deepltest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func postForm(t *testing.T, s *Server, path string, header http.Header, form url.Values) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, s.ServerURL()+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+func TestServer_Translate(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	res := postForm(t, s, "/translate", http.Header{}, url.Values{"text": {"proton beam"}, "target_lang": {"DE"}})
+	var body struct {
+		Translations []struct {
+			DetectedSourceLanguage string `json:"detected_source_language"`
+			Text                   string `json:"text"`
+		} `json:"translations"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Translations) != 1 || body.Translations[0].Text != "Protonenstrahl" || body.Translations[0].DetectedSourceLanguage != "EN" {
+		t.Errorf("unexpected translations %+v", body.Translations)
+	}
+	if got := s.CharacterCount(); got != len("proton beam") {
+		t.Errorf("got character count %d, want %d", got, len("proton beam"))
+	}
+}
+
+func TestServer_SessionHeaders(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	header := http.Header{}
+	header.Set(HeaderSession, "limits")
+	header.Set(HeaderSession429Count, "1")
+	header.Set(HeaderSessionCharacterLimit, "5")
+	form := url.Values{"text": {"proton beam"}, "target_lang": {"DE"}}
+	for _, want := range []int{http.StatusTooManyRequests, 456} {
+		if res := postForm(t, s, "/translate", header.Clone(), form); res.StatusCode != want {
+			t.Errorf("got status %d, want %d", res.StatusCode, want)
+		}
+	}
+	if res := postForm(t, s, "/translate", http.Header{}, form); res.StatusCode != http.StatusOK {
+		t.Errorf("requests without session should not be limited, got status %d", res.StatusCode)
+	}
+}
@@ -0,0 +1,20 @@
+package deepl
+
+import (
+	"strings"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// GroupByDetectedLanguage returns the indexes of translations grouped by their detected source language,
+// e.g. to route texts translated with consts.SourceLangAuto by language.
+// The indexes of every group are in ascending order and refer to the translated texts as well.
+func GroupByDetectedLanguage(translations []types.Translation) map[consts.SourceLang][]int {
+	groups := map[consts.SourceLang][]int{}
+	for i, translation := range translations {
+		lang := consts.SourceLang(strings.ToUpper(translation.DetectedSourceLanguage))
+		groups[lang] = append(groups[lang], i)
+	}
+	return groups
+}
@@ -0,0 +1,54 @@
+package deepl
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/deepltest"
+)
+
+func TestTranslator_SourceLangAuto(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+	translator, err := NewTranslator("auth_key", WithServerURL(server.ServerURL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	texts := []string{"Protonenstrahl", "proton beam", "faisceau de protons", "proton beam"}
+	translations, err := translator.TranslateText(context.Background(), texts, consts.SourceLangAuto, consts.TargetLangEnglishUS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, translation := range translations {
+		if translation.Text != "proton beam" {
+			t.Errorf("text %d translated to %q", i, translation.Text)
+		}
+	}
+	got := GroupByDetectedLanguage(translations)
+	want := map[consts.SourceLang][]int{
+		consts.SourceLangGerman:  {0},
+		consts.SourceLangEnglish: {1, 3},
+		consts.SourceLangFrench:  {2},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected groups (-want +got):\n%s", diff)
+	}
+}
+
+func TestTranslator_SourceLangAutoOmitted(t *testing.T) {
+	var requests int32
+	echo := echoServer(t, &requests)
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if _, ok := r.PostForm["source_lang"]; ok {
+			t.Errorf("source_lang sent for auto detection: %v", r.PostForm)
+		}
+		echo(w, r)
+	})
+	if _, err := translator.TranslateText(context.Background(), []string{"proton beam"}, consts.SourceLangAuto, consts.TargetLangGerman); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,342 @@
+package deepl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/google/uuid"
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// TranslateDocument uploads a document, waits until its translation is complete
+// and downloads the result to w.
+func (d *Translator) TranslateDocument(
+	ctx context.Context,
+	s consts.SourceLang,
+	t consts.TargetLang,
+	f io.Reader,
+	w io.Writer,
+	opts ...func(*types.DocumentTranslateOptions) error,
+) (status types.DocumentStatus, err error) {
+	ctx, end := d.observe(ctx, consts.OperationTranslateDocument)
+	defer end(&err)
+	options, err := documentTranslateOptions(w, opts)
+	if err != nil {
+		return status, err
+	}
+	doc, err := d.uploadDocument(ctx, s, t, f, options)
+	if err != nil {
+		return status, err
+	}
+	status, err = d.waitForDocument(ctx, doc, options)
+	if err != nil {
+		return status, err
+	}
+	err = d.downloadDocument(ctx, doc, options.OutputFile, options)
+	if err != nil {
+		return status, err
+	}
+	return status, nil
+}
+
+// UploadDocument uploads a document for translation and returns its handle.
+// The handle can be serialised as JSON and used to resume the translation later,
+// e.g. in another process, via GetDocumentStatus, WaitUntilDocumentTranslationFinished
+// and DownloadDocument.
+func (d *Translator) UploadDocument(
+	ctx context.Context,
+	s consts.SourceLang,
+	t consts.TargetLang,
+	f io.Reader,
+	opts ...func(*types.DocumentTranslateOptions) error,
+) (types.DocumentHandle, error) {
+	options, err := documentTranslateOptions(nil, opts)
+	if err != nil {
+		return types.DocumentHandle{}, err
+	}
+	return d.uploadDocument(ctx, s, t, f, options)
+}
+
+// documentTranslateOptions applies opts on top of the defaults.
+func documentTranslateOptions(w io.Writer, opts []func(*types.DocumentTranslateOptions) error) (types.DocumentTranslateOptions, error) {
+	options := types.DocumentTranslateOptions{OutputFile: w}
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return options, err
+		}
+	}
+	if options.FileName == "" {
+		options.FileName = uuid.New().String()
+	}
+	return options, nil
+}
+
+// uploadDocument uploads a document to the DeepL API.
+func (d *Translator) uploadDocument(
+	ctx context.Context,
+	s consts.SourceLang,
+	t consts.TargetLang,
+	file io.Reader,
+	options types.DocumentTranslateOptions,
+) (doc types.DocumentHandle, err error) {
+	ctx, end := d.observe(ctx, consts.OperationUploadDocument)
+	defer end(&err)
+	defer emitDocumentError(options, &doc, &err)
+	if catalog := d.languageCatalog(ctx); catalog != nil {
+		if err := catalog.ValidateTranslation(s, t, options.Formality); err != nil {
+			return doc, err
+		}
+	}
+	if err := d.reserveCharacters(ctx, 0); err != nil {
+		return doc, contextError(ctx, err)
+	}
+	// the upload is sent again if DeepL answers with 429
+	content, err := replayable(file)
+	if err != nil {
+		return doc, err
+	}
+	boundary := strings.Replace(uuid.New().String(), "-", "", -1)
+	contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
+	err = requests.
+		URL("/document").
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		BodyWriter(func(w io.Writer) error {
+			bodyWriter := multipart.NewWriter(w)
+			bodyWriter.SetBoundary(boundary)
+			for k, v := range documentUploadFields(s, t, options) {
+				if err := bodyWriter.WriteField(k, v); err != nil {
+					return err
+				}
+			}
+			fileWriter, err := bodyWriter.CreateFormFile("file", options.FileName)
+			if err != nil {
+				return err
+			}
+			// every attempt reads its own copy, the writer of a previous attempt may still be running
+			if _, err := io.Copy(fileWriter, content()); err != nil {
+				return err
+			}
+			return bodyWriter.Close()
+		}).
+		ContentType(contentType).
+		ToJSON(&doc).
+		Fetch(ctx)
+	if err != nil {
+		return doc, contextError(ctx, err)
+	}
+	emitDocumentEvent(options, types.DocumentEvent{Type: consts.DocumentEventUploaded, Document: doc})
+	return doc, nil
+}
+
+// replayable returns a function returning a new reader over the remaining content of file on every call,
+// so the content can be read again by independent readers. Files that cannot be read at an offset
+// are read into memory.
+func replayable(file io.Reader) (func() io.Reader, error) {
+	if readerAt, ok := file.(interface {
+		io.ReaderAt
+		io.Seeker
+	}); ok {
+		if offset, err := readerAt.Seek(0, io.SeekCurrent); err == nil {
+			if size, err := readerAt.Seek(0, io.SeekEnd); err == nil {
+				if _, err := readerAt.Seek(offset, io.SeekStart); err != nil {
+					return nil, err
+				}
+				return func() io.Reader { return io.NewSectionReader(readerAt, offset, size-offset) }, nil
+			}
+		}
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return func() io.Reader { return bytes.NewReader(content) }, nil
+}
+
+// documentUploadFields returns the form fields sent along with an uploaded document.
+// Unset options are omitted so the API defaults apply.
+func documentUploadFields(
+	s consts.SourceLang,
+	t consts.TargetLang,
+	options types.DocumentTranslateOptions,
+) map[string]string {
+	fields := map[string]string{
+		"target_lang": string(t),
+		"filename":    options.FileName,
+	}
+	if s != "" {
+		fields["source_lang"] = string(s)
+	}
+	if options.Formality != "" {
+		fields["formality"] = string(options.Formality)
+	}
+	if options.GlossaryID != "" {
+		fields["glossary_id"] = options.GlossaryID
+	}
+	if options.OutputFormat != "" {
+		fields["output_format"] = options.OutputFormat
+	}
+	return fields
+}
+
+// GetDocumentStatus returns the current status of a document translation.
+func (d *Translator) GetDocumentStatus(ctx context.Context, doc types.DocumentHandle) (types.DocumentStatus, error) {
+	var res types.DocumentStatus
+	if err := validateDocumentHandle(doc); err != nil {
+		return res, err
+	}
+	err := requests.
+		URL(fmt.Sprintf("/document/%s", doc.DocumentID)).
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		ContentType("application/x-www-form-urlencoded").
+		Param("document_key", doc.DocumentKey).
+		ToJSON(&res).
+		Fetch(ctx)
+	if err != nil {
+		return res, contextError(ctx, err)
+	}
+	return res, nil
+}
+
+// WaitUntilDocumentTranslationFinished polls the status of a document translation until it is done.
+// The wait between status checks is determined by the poll strategy of the Translator
+// unless overridden with WithDocumentPollStrategy.
+// A *DocumentTranslationError is returned if the translation failed and
+// ErrPollTimeout if it did not finish within the maximum wait time.
+func (d *Translator) WaitUntilDocumentTranslationFinished(
+	ctx context.Context,
+	doc types.DocumentHandle,
+	opts ...func(*types.DocumentTranslateOptions) error,
+) (types.DocumentStatus, error) {
+	options, err := documentTranslateOptions(nil, opts)
+	if err != nil {
+		return types.DocumentStatus{}, err
+	}
+	return d.waitForDocument(ctx, doc, options)
+}
+
+// waitForDocument polls the document status until the translation is done or failed.
+func (d *Translator) waitForDocument(
+	ctx context.Context,
+	doc types.DocumentHandle,
+	options types.DocumentTranslateOptions,
+) (status types.DocumentStatus, err error) {
+	ctx, end := d.observe(ctx, consts.OperationWaitDocument)
+	defer end(&err)
+	defer emitDocumentError(options, &doc, &err)
+	strategy := options.PollStrategy
+	if strategy == nil {
+		strategy = d.options.PollStrategy
+	}
+	if strategy == nil {
+		strategy = NewServerHintPollStrategy(time.Second, time.Minute)
+	}
+	maxWait := options.MaxPollWait
+	if maxWait <= 0 {
+		maxWait = d.options.MaxPollWait
+	}
+	var deadline time.Time
+	if maxWait > 0 {
+		deadline = time.Now().Add(maxWait)
+	}
+	status, err = d.GetDocumentStatus(ctx, doc)
+	if err != nil {
+		return status, err
+	}
+	for attempt := 1; !status.Done() && status.Ok(); attempt++ {
+		notifyDocumentStatus(options, doc, status)
+		interval := positiveInterval(strategy.NextInterval(attempt, status))
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return status, ErrPollTimeout
+			}
+			if interval > remaining {
+				interval = remaining
+			}
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status, contextError(ctx, ctx.Err())
+		case <-timer.C:
+		}
+		status, err = d.GetDocumentStatus(ctx, doc)
+		if err != nil {
+			return status, err
+		}
+	}
+	notifyDocumentStatus(options, doc, status)
+	if !status.Ok() {
+		return status, &DocumentTranslationError{DocumentID: doc.DocumentID, Message: status.ErrorMessage}
+	}
+	d.recordBilledCharacters(ctx, status)
+	return status, nil
+}
+
+// DownloadDocument downloads a finished document translation and writes it to w.
+// A *DocumentNotReadyError is returned if the translation is not finished yet.
+func (d *Translator) DownloadDocument(
+	ctx context.Context,
+	doc types.DocumentHandle,
+	w io.Writer,
+	opts ...func(*types.DocumentTranslateOptions) error,
+) error {
+	options, err := documentTranslateOptions(w, opts)
+	if err != nil {
+		return err
+	}
+	return d.downloadDocument(ctx, doc, w, options)
+}
+
+// downloadDocument downloads the translated document to w, reporting the progress to the options.
+func (d *Translator) downloadDocument(
+	ctx context.Context,
+	doc types.DocumentHandle,
+	w io.Writer,
+	options types.DocumentTranslateOptions,
+) (err error) {
+	ctx, end := d.observe(ctx, consts.OperationDownloadDocument)
+	defer end(&err)
+	defer emitDocumentError(options, &doc, &err)
+	if err := validateDocumentHandle(doc); err != nil {
+		return err
+	}
+	if w == nil {
+		return fmt.Errorf("%w: output file must not be nil", ErrInvalidOption)
+	}
+	err = requests.
+		URL(fmt.Sprintf("/document/%s/result", doc.DocumentID)).
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		ContentType("application/x-www-form-urlencoded").
+		Param("document_key", doc.DocumentKey).
+		Handle(func(res *http.Response) error {
+			pw := &progressWriter{w: w, doc: doc, total: res.ContentLength, options: options}
+			_, err := io.Copy(pw, res.Body)
+			return err
+		}).
+		Fetch(ctx)
+	if err != nil {
+		return contextError(ctx, err)
+	}
+	return nil
+}
+
+// validateDocumentHandle returns an error if the handle is missing its id or key.
+func validateDocumentHandle(doc types.DocumentHandle) error {
+	if doc.DocumentID == "" || doc.DocumentKey == "" {
+		return ErrInvalidDocumentHandle
+	}
+	return nil
+}
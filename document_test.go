@@ -0,0 +1,205 @@
+package deepl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// documentServer serves a single document translation which is done immediately.
+// Form fields and file of the upload are recorded in fields.
+func documentServer(t *testing.T, fields map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/document":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Error(err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for k, v := range r.MultipartForm.Value {
+				fields[k] = v[0]
+			}
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				t.Error(err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			content, _ := io.ReadAll(file)
+			fields["file"] = header.Filename + ":" + string(content)
+			w.Write([]byte(`{"document_id":"abc","document_key":"key"}`))
+		case "/v2/document/abc":
+			w.Write([]byte(`{"document_id":"abc","status":"done","billed_characters":10}`))
+		case "/v2/document/abc/result":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("Protonenstrahl"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestTranslator_UploadDocumentFields(t *testing.T) {
+	fields := map[string]string{}
+	translator := makeLocalTranslator(t, documentServer(t, fields))
+	var output bytes.Buffer
+	status, err := translator.TranslateDocument(context.Background(), consts.SourceLangEnglish, consts.TargetLangGerman, strings.NewReader("proton beam"), &output,
+		WithFileName("test.docx"),
+		WithDocumentFormality(consts.PreferLess),
+		WithDocumentGlossary("glossary"),
+		WithOutputFormat(".PDF"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"source_lang":   "EN",
+		"target_lang":   "DE",
+		"filename":      "test.docx",
+		"formality":     "prefer_less",
+		"glossary_id":   "glossary",
+		"output_format": "pdf",
+		"file":          "test.docx:proton beam",
+	}
+	if !cmp.Equal(fields, want) {
+		t.Errorf("upload fields: %s", cmp.Diff(want, fields))
+	}
+	if output.String() != "Protonenstrahl" {
+		t.Errorf("got output %q", output.String())
+	}
+	if status.BilledCharacters != 10 {
+		t.Errorf("got status %+v", status)
+	}
+}
+
+func TestTranslator_UploadDocumentOmitsUnsetFields(t *testing.T) {
+	fields := map[string]string{}
+	translator := makeLocalTranslator(t, documentServer(t, fields))
+	_, err := translator.TranslateDocument(context.Background(), "", consts.TargetLangGerman, strings.NewReader("proton beam"), io.Discard,
+		WithDocumentTranslateOptions(types.DocumentTranslateOptions{FileName: "test.txt"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{"source_lang", "formality", "glossary_id", "output_format"} {
+		if _, ok := fields[k]; ok {
+			t.Errorf("unset field %s was sent", k)
+		}
+	}
+}
+
+func TestTranslator_ResumeDocumentTranslation(t *testing.T) {
+	translator := makeLocalTranslator(t, documentServer(t, map[string]string{}))
+	doc, err := translator.UploadDocument(context.Background(), consts.SourceLangEnglish, consts.TargetLangGerman, strings.NewReader("proton beam"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	persisted, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// resume with a fresh translator and the persisted handle
+	resumed := makeLocalTranslator(t, documentServer(t, map[string]string{}))
+	var handle types.DocumentHandle
+	if err := json.Unmarshal(persisted, &handle); err != nil {
+		t.Fatal(err)
+	}
+	if handle != doc {
+		t.Fatalf("got handle %+v, want %+v", handle, doc)
+	}
+	if _, err := resumed.WaitUntilDocumentTranslationFinished(context.Background(), handle); err != nil {
+		t.Fatal(err)
+	}
+	var output bytes.Buffer
+	if err := resumed.DownloadDocument(context.Background(), handle, &output); err != nil {
+		t.Fatal(err)
+	}
+	if output.String() != "Protonenstrahl" {
+		t.Errorf("got output %q", output.String())
+	}
+	if _, err := resumed.GetDocumentStatus(context.Background(), types.DocumentHandle{}); !errors.Is(err, ErrInvalidDocumentHandle) {
+		t.Errorf("got %v, want ErrInvalidDocumentHandle", err)
+	}
+}
+
+func TestTranslator_DocumentProgressEvents(t *testing.T) {
+	translator := makeLocalTranslator(t, documentServer(t, map[string]string{}))
+	events := make(chan types.DocumentEvent, 16)
+	_, err := translator.TranslateDocument(context.Background(), consts.SourceLangEnglish, consts.TargetLangGerman, strings.NewReader("proton beam"), io.Discard,
+		WithProgressChannel(events),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	close(events)
+	var got []consts.DocumentEventType
+	var last types.DocumentEvent
+	for event := range events {
+		got = append(got, event.Type)
+		last = event
+	}
+	want := []consts.DocumentEventType{consts.DocumentEventUploaded, consts.DocumentEventDone, consts.DocumentEventDownloading}
+	if !cmp.Equal(got, want) {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+	if last.BytesDownloaded != int64(len("Protonenstrahl")) || last.TotalBytes != last.BytesDownloaded {
+		t.Errorf("got download progress %d of %d", last.BytesDownloaded, last.TotalBytes)
+	}
+
+	// an undrained channel does not block the translation
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = translator.TranslateDocument(ctx, consts.SourceLangEnglish, consts.TargetLangGerman, strings.NewReader("proton beam"), io.Discard,
+		WithProgressChannel(make(chan types.DocumentEvent)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failing := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	var errEvent types.DocumentEvent
+	_, err = failing.TranslateDocument(context.Background(), consts.SourceLangEnglish, consts.TargetLangGerman, strings.NewReader("proton beam"), io.Discard,
+		WithProgress(func(event types.DocumentEvent) { errEvent = event }),
+	)
+	if err == nil || errEvent.Type != consts.DocumentEventError || errEvent.Err == nil {
+		t.Errorf("got event %+v for error %v", errEvent, err)
+	}
+}
+
+func TestReplayable(t *testing.T) {
+	seeker := strings.NewReader("skip:content")
+	seeker.Seek(5, io.SeekStart)
+	for _, file := range []io.Reader{seeker, io.MultiReader(strings.NewReader("content"))} {
+		content, err := replayable(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		first, second := content(), content()
+		// readers are independent, reading one does not advance the other
+		buf := make([]byte, 3)
+		io.ReadFull(first, buf)
+		rest, _ := io.ReadAll(second)
+		if string(rest) != "content" {
+			t.Errorf("got %q, want content", rest)
+		}
+		rest, _ = io.ReadAll(first)
+		if string(buf)+string(rest) != "content" {
+			t.Errorf("got %q, want content", string(buf)+string(rest))
+		}
+	}
+}
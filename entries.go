@@ -1,15 +1,83 @@
 package deepl
 
 import (
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// ErrInvalidGlossaryEntries is returned when glossary entries cannot be parsed or are invalid.
+var ErrInvalidGlossaryEntries = errors.New("deepl: invalid glossary entries")
+
+// maxGlossarySize is the maximum size of the entries of a glossary accepted by DeepL.
+const maxGlossarySize = 10 << 20
+
+// GlossaryEntryError describes a single invalid glossary entry. Line is set for parsed entries.
+type GlossaryEntryError struct {
+	Line   int
+	Source string
+	Err    error
+}
+
+func (e *GlossaryEntryError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("entry %q: %v", e.Source, e.Err)
+}
+
+func (e *GlossaryEntryError) Unwrap() error { return e.Err }
+
+// GlossaryValidationError lists every problem found in glossary entries, so all of them can be fixed at once.
+// It matches ErrInvalidGlossaryEntries.
+type GlossaryValidationError struct {
+	Problems []*GlossaryEntryError
+}
+
+func (e *GlossaryValidationError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		msgs[i] = problem.Error()
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidGlossaryEntries, strings.Join(msgs, "; "))
+}
+
+func (e *GlossaryValidationError) Unwrap() []error {
+	errs := []error{ErrInvalidGlossaryEntries}
+	for _, problem := range e.Problems {
+		errs = append(errs, problem)
+	}
+	return errs
+}
+
+// validationReport collects the problems of glossary entries.
+type validationReport struct {
+	problems []*GlossaryEntryError
+}
+
+func (r *validationReport) add(line int, source string, err error) {
+	r.problems = append(r.problems, &GlossaryEntryError{Line: line, Source: source, Err: err})
+}
+
+// err returns the report as GlossaryValidationError, nil if there are no problems.
+func (r *validationReport) err() error {
+	if len(r.problems) == 0 {
+		return nil
+	}
+	return &GlossaryValidationError{Problems: r.problems}
+}
+
 type GlossaryEntries struct {
 	Entries map[string]string
 }
 
 // NewGlossaryEntries creates a new GlossaryEntries object from a map or a tsv string.
+// All entries are validated and every invalid entry is reported in a GlossaryValidationError.
 func NewGlossaryEntries(entries interface{}) (*GlossaryEntries, error) {
 	g := &GlossaryEntries{
 		Entries: make(map[string]string),
@@ -17,55 +85,191 @@ func NewGlossaryEntries(entries interface{}) (*GlossaryEntries, error) {
 	switch entries.(type) {
 	case map[string]string:
 		g.Entries = entries.(map[string]string)
+		return g, g.validate(false)
 	case string:
-		if tsv, err := g.fromTSV(entries.(string)); err != nil {
-			return g, err
-		} else {
-			g.Entries = tsv
-		}
+		tsv, err := g.fromTSV(entries.(string))
+		g.Entries = tsv
+		return g, err
 	default:
 		return g, fmt.Errorf("invalid type for entries")
 	}
-	return g, nil
 }
 
+// NewGlossaryEntriesFromCSV creates a new GlossaryEntries object from csv with one source and target term per record.
+func NewGlossaryEntriesFromCSV(entries string) (*GlossaryEntries, error) {
+	g := &GlossaryEntries{}
+	result, err := g.fromCSV(entries)
+	g.Entries = result
+	return g, err
+}
+
+// ToTSV returns the entries in the tsv format, sorted by source term so the output is stable.
 func (g *GlossaryEntries) ToTSV() string {
-	result := make([]string, 0)
-	for k, v := range g.Entries {
-		result = append(result, fmt.Sprintf("%s\t%s", k, v))
+	sources := g.sortedSources()
+	result := make([]string, 0, len(sources))
+	for _, source := range sources {
+		result = append(result, fmt.Sprintf("%s\t%s", source, g.Entries[source]))
 	}
 	return strings.Join(result, "\n")
 }
 
+// ToCSV returns the entries in the csv format, sorted by source term. Terms are quoted where needed.
+func (g *GlossaryEntries) ToCSV() string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	for _, source := range g.sortedSources() {
+		// writing to a strings.Builder cannot fail
+		w.Write([]string{source, g.Entries[source]})
+	}
+	w.Flush()
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (g *GlossaryEntries) sortedSources() []string {
+	sources := make([]string, 0, len(g.Entries))
+	for source := range g.Entries {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// fromTSV parses tsv entries. Line endings may be LF or CRLF, blank lines are skipped
+// and surrounding whitespace of the terms is removed.
 func (g *GlossaryEntries) fromTSV(entries string) (map[string]string, error) {
 	result := make(map[string]string)
-	for _, entry := range strings.Split(entries, "\n") {
+	report := &validationReport{}
+	for i, entry := range strings.Split(entries, "\n") {
+		entry = strings.TrimSuffix(entry, "\r")
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
 		parts := strings.Split(entry, "\t")
-		if len(parts) == 2 {
-			result[parts[0]] = parts[1]
-		} else {
-			return result, fmt.Errorf("tab missing in entry: %s", entry)
+		if len(parts) != 2 {
+			report.add(i+1, "", fmt.Errorf("expected source and target term separated by one tab: %q", entry))
+			continue
+		}
+		g.addParsedEntry(result, report, i+1, parts[0], parts[1])
+	}
+	return result, report.err()
+}
+
+// fromCSV parses csv entries with the same rules as fromTSV.
+func (g *GlossaryEntries) fromCSV(entries string) (map[string]string, error) {
+	result := make(map[string]string)
+	report := &validationReport{}
+	r := csv.NewReader(strings.NewReader(entries))
+	r.FieldsPerRecord = -1
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return result, report.err()
 		}
+		if err != nil {
+			// the reader cannot continue after syntax errors
+			var parseErr *csv.ParseError
+			line := 0
+			if errors.As(err, &parseErr) {
+				line = parseErr.Line
+			}
+			report.add(line, "", err)
+			return result, report.err()
+		}
+		line, _ := r.FieldPos(0)
+		if len(record) != 2 {
+			report.add(line, "", fmt.Errorf("expected source and target term, got %d fields", len(record)))
+			continue
+		}
+		g.addParsedEntry(result, report, line, record[0], record[1])
+	}
+}
+
+// addParsedEntry adds a parsed entry with trimmed terms, reporting invalid terms and duplicate source terms.
+func (g *GlossaryEntries) addParsedEntry(entries map[string]string, report *validationReport, line int, source, target string) {
+	source = strings.TrimSpace(source)
+	target = strings.TrimSpace(target)
+	valid := true
+	for _, term := range []string{source, target} {
+		if err := g.validateGlossaryTerm(term); err != nil {
+			report.add(line, source, err)
+			valid = false
+		}
+	}
+	if !valid {
+		return
 	}
-	return result, nil
+	if _, ok := entries[source]; ok {
+		report.add(line, source, fmt.Errorf("duplicate source term %q", source))
+		return
+	}
+	entries[source] = target
 }
 
+// Add adds an entry after validating both terms. Existing entries are only replaced if overwrite is set,
+// otherwise a duplicate source term is reported as GlossaryValidationError.
 func (g *GlossaryEntries) Add(source string, target string, overwrite bool) error {
-	if !overwrite && g.Entries[source] != "" {
-		return fmt.Errorf("entry already exists")
+	report := &validationReport{}
+	for _, term := range []string{source, target} {
+		if err := g.validateGlossaryTerm(term); err != nil {
+			report.add(0, source, err)
+		}
+	}
+	if _, ok := g.Entries[source]; ok && !overwrite {
+		report.add(0, source, fmt.Errorf("duplicate source term %q", source))
+	}
+	if err := report.err(); err != nil {
+		return err
+	}
+	if g.Entries == nil {
+		g.Entries = make(map[string]string)
 	}
 	g.Entries[source] = target
 	return nil
 }
 
+// Validate checks all entries and reports every invalid term in a GlossaryValidationError.
+// Glossaries without entries or larger than DeepL accepts are invalid as well.
+func (g *GlossaryEntries) Validate() error {
+	return g.validate(true)
+}
+
+// validate checks all entries, and the number and size of the entries if complete is set.
+func (g *GlossaryEntries) validate(complete bool) error {
+	report := &validationReport{}
+	for _, source := range g.sortedSources() {
+		for _, term := range []string{source, g.Entries[source]} {
+			if err := g.validateGlossaryTerm(term); err != nil {
+				report.add(0, source, err)
+			}
+		}
+	}
+	if complete {
+		if len(g.Entries) == 0 {
+			report.add(0, "", errors.New("no entries provided"))
+		} else if size := len(g.ToTSV()); size > maxGlossarySize {
+			report.add(0, "", fmt.Errorf("entries have %d bytes, at most %d are allowed", size, maxGlossarySize))
+		}
+	}
+	return report.err()
+}
+
+// validateGlossaryTerm checks that term is not empty, has no surrounding whitespace and no control characters.
 func (g *GlossaryEntries) validateGlossaryTerm(term string) error {
 	if term == "" {
 		return fmt.Errorf("term is empty")
 	}
+	if !utf8.ValidString(term) {
+		return fmt.Errorf("term %q is not valid UTF-8", term)
+	}
 	for i, v := range term {
 		if (0 <= v && v <= 31) || (128 <= v && v <= 159) || v == 0x2028 || v == 0x2029 {
-			return fmt.Errorf("term %s contains invalid character at position %d", term, i)
+			return fmt.Errorf("term %q contains invalid character at position %d", term, i)
 		}
 	}
+	first, _ := utf8.DecodeRuneInString(term)
+	last, _ := utf8.DecodeLastRuneInString(term)
+	if unicode.IsSpace(first) || unicode.IsSpace(last) {
+		return fmt.Errorf("term %q has leading or trailing whitespace", term)
+	}
 	return nil
 }
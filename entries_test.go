@@ -0,0 +1,175 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hsedr/deepl-golang/consts"
+)
+
+func TestGlossaryEntries_RoundTrip(t *testing.T) {
+	entries := map[string]string{
+		"proton":          "Protonen",
+		"beam":            "Strahl",
+		"a, b":            `"Zitat"`,
+		"Straße":          "street",
+		`say "hi", "bye"`: "sag hallo",
+	}
+	g, _ := NewGlossaryEntries(entries)
+
+	tsv, err := NewGlossaryEntries(g.ToTSV())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(entries, tsv.Entries); diff != "" {
+		t.Errorf("tsv round trip (-want +got):\n%s", diff)
+	}
+	csv, err := NewGlossaryEntriesFromCSV(g.ToCSV())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(entries, csv.Entries); diff != "" {
+		t.Errorf("csv round trip (-want +got):\n%s", diff)
+	}
+	if g.ToCSV() != csv.ToCSV() || g.ToTSV() != tsv.ToTSV() {
+		t.Error("serialisation is not stable")
+	}
+}
+
+func TestGlossaryEntries_ToCSV(t *testing.T) {
+	g, _ := NewGlossaryEntries(map[string]string{"proton": "Protonen", "a, b": `"c"`})
+	want := "\"a, b\",\"\"\"c\"\"\"\nproton,Protonen"
+	if got := g.ToCSV(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestGlossaryEntries_TolerantParsing(t *testing.T) {
+	want := map[string]string{"proton": "Protonen", "beam": "Strahl"}
+	inputs := []string{
+		"proton\tProtonen\r\nbeam\tStrahl\r\n",
+		"\nproton\tProtonen\n\n  \nbeam\tStrahl\n\n",
+		" proton \t Protonen\nbeam\tStrahl",
+	}
+	for _, input := range inputs {
+		g, err := NewGlossaryEntries(input)
+		if err != nil {
+			t.Errorf("%q: %v", input, err)
+			continue
+		}
+		if diff := cmp.Diff(want, g.Entries); diff != "" {
+			t.Errorf("%q (-want +got):\n%s", input, diff)
+		}
+	}
+	g, err := NewGlossaryEntriesFromCSV("proton,Protonen\r\n\r\nbeam,Strahl\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, g.Entries); diff != "" {
+		t.Errorf("csv (-want +got):\n%s", diff)
+	}
+}
+
+func TestGlossaryEntries_ParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		csv   bool
+		line  string
+	}{
+		{"proton\tProtonen\n\nbeam Strahl", false, "line 3"},
+		{"proton\tProtonen\nproton\tProton", false, "line 2"},
+		{"proton\t\n", false, "line 1"},
+		{"proton,Protonen\nbeam,Strahl,x", true, "line 2"},
+		{"proton,Protonen\n\nproton,Proton", true, "line 3"},
+	}
+	for _, test := range tests {
+		var err error
+		if test.csv {
+			_, err = NewGlossaryEntriesFromCSV(test.input)
+		} else {
+			_, err = NewGlossaryEntries(test.input)
+		}
+		if !errors.Is(err, ErrInvalidGlossaryEntries) || !strings.Contains(err.Error(), test.line) {
+			t.Errorf("%q: got %v, want ErrInvalidGlossaryEntries at %s", test.input, err, test.line)
+		}
+	}
+}
+
+func TestGlossaryEntries_ValidationReport(t *testing.T) {
+	_, err := NewGlossaryEntries("proton\tProtonen\n beam \t\nbeam\tStrahl\nbeam\tStrahlen\nx")
+	var report *GlossaryValidationError
+	if !errors.As(err, &report) || !errors.Is(err, ErrInvalidGlossaryEntries) {
+		t.Fatalf("got %v, want GlossaryValidationError", err)
+	}
+	var lines []int
+	for _, problem := range report.Problems {
+		lines = append(lines, problem.Line)
+	}
+	if want := []int{2, 4, 5}; !cmp.Equal(lines, want) {
+		t.Errorf("got problems at lines %v, want %v", lines, want)
+	}
+
+	_, err = NewGlossaryEntries(map[string]string{" proton": "Protonen", "beam": "", "ion": "Ion"})
+	if !errors.As(err, &report) || len(report.Problems) != 2 {
+		t.Fatalf("got %v, want two problems", err)
+	}
+	if report.Problems[0].Source != " proton" || report.Problems[1].Source != "beam" {
+		t.Errorf("got problems %v, want sorted by source", report.Problems)
+	}
+}
+
+func TestGlossaryEntries_Validate(t *testing.T) {
+	entries := &GlossaryEntries{}
+	if err := entries.Validate(); !errors.Is(err, ErrInvalidGlossaryEntries) {
+		t.Errorf("empty entries: got %v, want ErrInvalidGlossaryEntries", err)
+	}
+	if err := entries.Add("proton ", "Protonen", false); !errors.Is(err, ErrInvalidGlossaryEntries) {
+		t.Errorf("Add: got %v, want ErrInvalidGlossaryEntries", err)
+	}
+	if err := entries.Add("proton", "Protonen", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := entries.Validate(); err != nil {
+		t.Errorf("got %v, want valid entries", err)
+	}
+	var validationErr *GlossaryValidationError
+	if err := entries.Add("proton", "Proton", false); !errors.As(err, &validationErr) || !errors.Is(err, ErrInvalidGlossaryEntries) {
+		t.Errorf("duplicate Add: got %v, want GlossaryValidationError", err)
+	}
+	if err := entries.Add("proton", "Proton", true); err != nil {
+		t.Errorf("overwriting Add: got %v", err)
+	}
+	entries.Add("beam", strings.Repeat("x", maxGlossarySize), false)
+	if err := entries.Validate(); err == nil || !strings.Contains(err.Error(), "bytes") {
+		t.Errorf("got %v, want size error", err)
+	}
+}
+
+func TestTranslator_CreateGlossaryCSV(t *testing.T) {
+	translator, err := MakeTranslator(t, map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	entries, err := NewGlossaryEntriesFromCSV("\"proton, beam\",Protonenstrahl\nion,Ion")
+	if err != nil {
+		t.Fatal(err)
+	}
+	glossary, err := translator.CreateGlossary(ctx, "csv", consts.SourceLangEnglish, consts.TargetLangGerman, *entries, WithEntriesFormat("csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := translator.GetGlossaryEntries(ctx, glossary.GlossaryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(got.Entries, entries.Entries) {
+		t.Errorf("got entries %v, want %v", got.Entries, entries.Entries)
+	}
+	if _, err := translator.CreateGlossary(ctx, "xml", consts.SourceLangEnglish, consts.TargetLangGerman, *entries, WithEntriesFormat("xml")); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("got %v, want ErrInvalidOption", err)
+	}
+}
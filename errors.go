@@ -0,0 +1,210 @@
+package deepl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize limits how much of an error response body is read.
+const maxErrorBodySize = 64 << 10
+
+// APIError is returned when the DeepL API responds with an unexpected status code.
+// All more specific error types wrap an APIError, so errors.As can be used
+// with either the specific type or *APIError.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Detail     string
+	Path       string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("deepl: %s %d (%s)", e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Detail != "" {
+		msg += ", " + e.Detail
+	}
+	return msg
+}
+
+// BadRequestError is returned for status 400, e.g. invalid parameters.
+type BadRequestError struct{ APIError }
+
+func (e *BadRequestError) Unwrap() error { return &e.APIError }
+
+// AuthorizationError is returned for status 403, e.g. an invalid auth key.
+type AuthorizationError struct{ APIError }
+
+func (e *AuthorizationError) Unwrap() error { return &e.APIError }
+
+// NotFoundError is returned for status 404 on non-glossary resources.
+type NotFoundError struct{ APIError }
+
+func (e *NotFoundError) Unwrap() error { return &e.APIError }
+
+// GlossaryNotFoundError is returned for status 404 on glossary resources.
+type GlossaryNotFoundError struct{ APIError }
+
+func (e *GlossaryNotFoundError) Unwrap() error { return &e.APIError }
+
+// Is lets errors.Is match a GlossaryNotFoundError with ErrGlossaryNotFound.
+func (e *GlossaryNotFoundError) Is(target error) bool { return target == ErrGlossaryNotFound }
+
+// PayloadTooLargeError is returned for status 413, e.g. a request or document exceeding size limits.
+type PayloadTooLargeError struct{ APIError }
+
+func (e *PayloadTooLargeError) Unwrap() error { return &e.APIError }
+
+// TooManyRequestsError is returned for status 429 once all retries are exhausted.
+type TooManyRequestsError struct{ APIError }
+
+func (e *TooManyRequestsError) Unwrap() error { return &e.APIError }
+
+// QuotaExceededError is returned for status 456, the character limit has been reached.
+type QuotaExceededError struct{ APIError }
+
+func (e *QuotaExceededError) Unwrap() error { return &e.APIError }
+
+// DocumentNotReadyError is returned for status 503 when downloading a document
+// whose translation has not finished yet.
+type DocumentNotReadyError struct{ APIError }
+
+func (e *DocumentNotReadyError) Unwrap() error { return &e.APIError }
+
+// ServiceUnavailableError is returned for any other status 5xx.
+type ServiceUnavailableError struct{ APIError }
+
+func (e *ServiceUnavailableError) Unwrap() error { return &e.APIError }
+
+// DocumentTranslationError is returned when DeepL reports an error status for a document translation.
+type DocumentTranslationError struct {
+	DocumentID string
+	Message    string
+}
+
+func (e *DocumentTranslationError) Error() string {
+	return fmt.Sprintf("deepl: translation of document %s failed: %s", e.DocumentID, e.Message)
+}
+
+// checkStatusCode is a response validator that converts non-success responses
+// into the matching error type.
+func checkStatusCode(res *http.Response) error {
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+	apiErr := APIError{StatusCode: res.StatusCode}
+	if res.Request != nil && res.Request.URL != nil {
+		apiErr.Path = res.Request.URL.Path
+	}
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	var message struct {
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &message); err == nil {
+		apiErr.Message = message.Message
+		apiErr.Detail = message.Detail
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	switch {
+	case res.StatusCode == http.StatusBadRequest:
+		return &BadRequestError{apiErr}
+	case res.StatusCode == http.StatusForbidden:
+		return &AuthorizationError{apiErr}
+	case res.StatusCode == http.StatusNotFound && strings.Contains(apiErr.Path, "/glossaries"):
+		return &GlossaryNotFoundError{apiErr}
+	case res.StatusCode == http.StatusNotFound:
+		return &NotFoundError{apiErr}
+	case res.StatusCode == http.StatusRequestEntityTooLarge:
+		return &PayloadTooLargeError{apiErr}
+	case res.StatusCode == http.StatusTooManyRequests:
+		return &TooManyRequestsError{apiErr}
+	case res.StatusCode == 456:
+		return &QuotaExceededError{apiErr}
+	case res.StatusCode == http.StatusServiceUnavailable && strings.HasSuffix(apiErr.Path, "/result"):
+		return &DocumentNotReadyError{apiErr}
+	case res.StatusCode >= 500:
+		return &ServiceUnavailableError{apiErr}
+	}
+	return &apiErr
+}
+
+// ErrInvalidOption is returned when an option is given an invalid value.
+var ErrInvalidOption = errors.New("deepl: invalid option")
+
+// ErrInvalidDocumentHandle is returned when a document handle is missing its id or key.
+var ErrInvalidDocumentHandle = errors.New("deepl: document handle must have a document id and key")
+
+// ErrPollTimeout is returned when a document translation does not finish or a glossary does not become ready
+// within the maximum wait time.
+var ErrPollTimeout = errors.New("deepl: polling did not finish within the maximum wait time")
+
+// ErrGlossaryNotFound is returned when no glossary has the name looked up.
+// A GlossaryNotFoundError returned by the API matches it as well.
+var ErrGlossaryNotFound = errors.New("deepl: glossary not found")
+
+// ErrOutputConflict is returned for a document of a batch whose output path is used by another document of the batch.
+var ErrOutputConflict = errors.New("deepl: output path conflicts with another document")
+
+// ErrTextTooLarge is returned when a single text exceeds the maximum size of a translate request.
+var ErrTextTooLarge = errors.New("deepl: text exceeds the maximum request size")
+
+// ChunkError is the error of a single request of a split text translation.
+// Start and End are the indexes of the affected texts.
+type ChunkError struct {
+	Start int
+	End   int
+	Err   error
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("texts %d to %d: %v", e.Start, e.End-1, e.Err)
+}
+
+func (e *ChunkError) Unwrap() error { return e.Err }
+
+// PartialTranslationError is returned when some requests of a split text translation failed.
+// The translations of the failed texts are left empty.
+type PartialTranslationError struct {
+	Failed []*ChunkError
+}
+
+func (e *PartialTranslationError) Error() string {
+	msgs := make([]string, len(e.Failed))
+	for i, err := range e.Failed {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("deepl: %d requests failed: %s", len(e.Failed), strings.Join(msgs, "; "))
+}
+
+func (e *PartialTranslationError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, err := range e.Failed {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ErrCanceled is returned when an operation is aborted because its context
+// was canceled or its deadline exceeded. The context error is wrapped as well.
+var ErrCanceled = errors.New("deepl: operation canceled")
+
+// contextError wraps err as ErrCanceled if ctx is done, otherwise err is returned unchanged.
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("%w: %w", ErrCanceled, ctxErr)
+	}
+	return err
+}
+
+// ErrBudgetExceeded is returned when a call would exceed the character budget of the quota guard,
+// see WithQuotaGuard. Nothing is sent to DeepL in that case.
+var ErrBudgetExceeded = errors.New("deepl: character budget exceeded")
@@ -0,0 +1,55 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/anthdm/tasker"
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+func TestCheckStatusCode_ErrorTypes(t *testing.T) {
+	tests := []struct {
+		status int
+		path   string
+		check  func(error) bool
+	}{
+		{403, "/usage", func(err error) bool { var e *AuthorizationError; return errors.As(err, &e) }},
+		{456, "/translate", func(err error) bool { var e *QuotaExceededError; return errors.As(err, &e) }},
+		{413, "/translate", func(err error) bool { var e *PayloadTooLargeError; return errors.As(err, &e) }},
+		{404, "/glossaries/abc", func(err error) bool { var e *GlossaryNotFoundError; return errors.As(err, &e) }},
+		{503, "/document/abc/result", func(err error) bool { var e *DocumentNotReadyError; return errors.As(err, &e) }},
+	}
+	for _, tt := range tests {
+		translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(tt.status)
+			w.Write([]byte(`{"message":"failure","detail":"details"}`))
+		})
+		var err error
+		switch tt.path {
+		case "/usage":
+			_, err = tasker.Spawn(translator.GetUsageAsync()).Await()
+		case "/translate":
+			_, err = tasker.Spawn(translator.TranslateTextAsync([]string{"proton beam"}, consts.SourceLangEnglish, consts.TargetLangGerman)).Await()
+		case "/glossaries/abc":
+			_, err = tasker.Spawn(translator.GetGlossaryDetailsAsync("abc")).Await()
+		case "/document/abc/result":
+			err = translator.DownloadDocument(context.Background(), types.DocumentHandle{DocumentID: "abc", DocumentKey: "key"}, io.Discard)
+		}
+		if !tt.check(err) {
+			t.Errorf("status %d on %s: unexpected error type %T: %v", tt.status, tt.path, err, err)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("status %d on %s: error is not an *APIError", tt.status, tt.path)
+		}
+		if apiErr.StatusCode != tt.status || apiErr.Message != "failure" || apiErr.Detail != "details" {
+			t.Errorf("got %+v", apiErr)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package deepl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// FindGlossaryByName returns the glossary with the name. If several glossaries have the name,
+// the newest one by CreationTime is returned, ties are broken by the smallest id.
+// ErrGlossaryNotFound is returned if no glossary has the name.
+func (d *Translator) FindGlossaryByName(ctx context.Context, name string) (_ types.Glossary, err error) {
+	ctx, end := d.observe(ctx, consts.OperationFindGlossary)
+	defer end(&err)
+	glossaries, err := d.GetGlossaries(ctx)
+	if err != nil {
+		return types.Glossary{}, err
+	}
+	var matches []types.Glossary
+	for _, glossary := range glossaries {
+		if glossary.Name == name {
+			matches = append(matches, glossary)
+		}
+	}
+	if len(matches) == 0 {
+		return types.Glossary{}, fmt.Errorf("%w: no glossary named %q", ErrGlossaryNotFound, name)
+	}
+	sortNewestFirst(matches)
+	return matches[0], nil
+}
+
+// WaitUntilGlossaryReady polls the details of a glossary until it is ready and returns them.
+// It checks every second unless configured with WithGlossaryPollInterval, and returns ErrPollTimeout
+// if the glossary is not ready within the time set with WithGlossaryMaxWait.
+func (d *Translator) WaitUntilGlossaryReady(
+	ctx context.Context,
+	id string,
+	opts ...func(*types.GlossaryWaitOptions) error,
+) (glossary types.Glossary, err error) {
+	ctx, end := d.observe(ctx, consts.OperationWaitGlossary)
+	defer end(&err)
+	options := types.GlossaryWaitOptions{Interval: defaultPollInterval}
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return glossary, err
+		}
+	}
+	var deadline time.Time
+	if options.MaxWait > 0 {
+		deadline = time.Now().Add(options.MaxWait)
+	}
+	glossary, err = d.GetGlossaryDetails(ctx, id)
+	for err == nil && !glossary.Ready {
+		interval := positiveInterval(options.Interval)
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return glossary, ErrPollTimeout
+			}
+			if interval > remaining {
+				interval = remaining
+			}
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return glossary, contextError(ctx, ctx.Err())
+		case <-timer.C:
+		}
+		glossary, err = d.GetGlossaryDetails(ctx, id)
+	}
+	return glossary, err
+}
+
+// WithGlossaryPollInterval sets the time between checks of WaitUntilGlossaryReady.
+func WithGlossaryPollInterval(interval time.Duration) func(*types.GlossaryWaitOptions) error {
+	return func(options *types.GlossaryWaitOptions) error {
+		if interval <= 0 {
+			return fmt.Errorf("%w: glossary poll interval must be positive", ErrInvalidOption)
+		}
+		options.Interval = interval
+		return nil
+	}
+}
+
+// WithGlossaryMaxWait sets the maximum time WaitUntilGlossaryReady waits for a glossary to become ready.
+func WithGlossaryMaxWait(maxWait time.Duration) func(*types.GlossaryWaitOptions) error {
+	return func(options *types.GlossaryWaitOptions) error {
+		if maxWait <= 0 {
+			return fmt.Errorf("%w: glossary max wait must be positive", ErrInvalidOption)
+		}
+		options.MaxWait = maxWait
+		return nil
+	}
+}
+
+// sortNewestFirst sorts glossaries by CreationTime, newest first, and glossaries created at the same time by id.
+func sortNewestFirst(glossaries []types.Glossary) {
+	sort.Slice(glossaries, func(i, j int) bool {
+		if !glossaries[i].CreationTime.Equal(glossaries[j].CreationTime) {
+			return glossaries[i].CreationTime.After(glossaries[j].CreationTime)
+		}
+		return glossaries[i].GlossaryID < glossaries[j].GlossaryID
+	})
+}
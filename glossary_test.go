@@ -0,0 +1,86 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/deepltest"
+)
+
+func TestTranslator_FindGlossaryByName(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+	translator, err := NewTranslator("auth_key", WithServerURL(server.ServerURL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	entries := mustEntries(t, map[string]string{"beam": "Strahl"})
+	var newest string
+	for _, name := range []string{"physics", "chemistry", "physics"} {
+		glossary, err := translator.CreateGlossary(ctx, name, consts.SourceLangEnglish, consts.TargetLangGerman, entries)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name == "physics" {
+			newest = glossary.GlossaryID
+		}
+	}
+	glossary, err := translator.FindGlossaryByName(ctx, "physics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if glossary.GlossaryID != newest {
+		t.Errorf("got glossary %s, want newest %s", glossary.GlossaryID, newest)
+	}
+	if _, err := translator.FindGlossaryByName(ctx, "biology"); !errors.Is(err, ErrGlossaryNotFound) {
+		t.Errorf("got %v, want ErrGlossaryNotFound", err)
+	}
+	if _, err := translator.GetGlossaryDetails(ctx, "missing"); !errors.Is(err, ErrGlossaryNotFound) {
+		t.Errorf("got %v, want GlossaryNotFoundError to match ErrGlossaryNotFound", err)
+	}
+}
+
+func TestTranslator_WaitUntilGlossaryReady(t *testing.T) {
+	server := deepltest.NewServer(deepltest.WithGlossaryReadyTime(200 * time.Millisecond))
+	defer server.Close()
+	translator, err := NewTranslator("auth_key", WithServerURL(server.ServerURL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	created, err := translator.CreateGlossary(ctx, "physics", consts.SourceLangEnglish, consts.TargetLangGerman, mustEntries(t, map[string]string{"beam": "Strahl"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Ready {
+		t.Fatal("glossary is ready right after creation")
+	}
+	glossary, err := translator.WaitUntilGlossaryReady(ctx, created.GlossaryID, WithGlossaryPollInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !glossary.Ready {
+		t.Error("glossary is not ready")
+	}
+
+	created, err = translator.CreateGlossary(ctx, "physics", consts.SourceLangEnglish, consts.TargetLangGerman, mustEntries(t, map[string]string{"beam": "Strahl"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	canceled, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := translator.WaitUntilGlossaryReady(canceled, created.GlossaryID, WithGlossaryPollInterval(10*time.Millisecond)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+	_, err = translator.WaitUntilGlossaryReady(ctx, created.GlossaryID, WithGlossaryPollInterval(10*time.Millisecond), WithGlossaryMaxWait(5*time.Millisecond))
+	if !errors.Is(err, ErrPollTimeout) {
+		t.Errorf("got %v, want ErrPollTimeout", err)
+	}
+	if _, err := translator.WaitUntilGlossaryReady(ctx, created.GlossaryID, WithGlossaryPollInterval(0)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("got %v, want ErrInvalidOption", err)
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/hsedr/deepl-golang
 
-go 1.20
+go 1.21
 
 require (
 	github.com/anthdm/tasker v0.0.0-20221211183213-9ddc090ec7c4
@@ -10,7 +10,6 @@ require (
 require (
 	github.com/carlmjohnson/requests v0.23.2
 	github.com/google/uuid v1.3.0
-	github.com/ybbus/httpretry v1.0.2
 )
 
 require (
@@ -0,0 +1,105 @@
+package deepl
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redacted replaces sensitive values in logs.
+const redacted = "REDACTED"
+
+// maxLoggedBody is the maximum number of body bytes logged by an unredacted logger.
+const maxLoggedBody = 1 << 10
+
+// sensitiveParams are redacted from logged query strings and bodies.
+var sensitiveParams = []string{"auth_key", "document_key", "text", "entries", "context"}
+
+// logAttempt logs a single attempt of a request. Successful requests are logged at debug level,
+// failed attempts at warn level and failed requests that are not retried at error level.
+func (t *Transport) logAttempt(
+	req *http.Request,
+	requestID string,
+	attempt int,
+	duration time.Duration,
+	res *http.Response,
+	err error,
+	retry bool,
+) {
+	if t.Logger == nil {
+		return
+	}
+	level := slog.LevelDebug
+	failed := err != nil || res.StatusCode >= 400
+	switch {
+	case failed && retry:
+		level = slog.LevelWarn
+	case failed:
+		level = slog.LevelError
+	}
+	ctx := req.Context()
+	if !t.Logger.Enabled(ctx, level) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.String("request_id", requestID),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", duration),
+	}
+	if query := t.logQuery(req.URL.Query()); query != "" {
+		attrs = append(attrs, slog.String("query", query))
+	}
+	if res != nil {
+		attrs = append(attrs, slog.Int("status", res.StatusCode))
+		if traceID := res.Header.Get("X-Trace-ID"); traceID != "" {
+			attrs = append(attrs, slog.String("trace_id", traceID))
+		}
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	if retry {
+		attrs = append(attrs, slog.Bool("retry", true))
+	}
+	if t.LogUnredacted {
+		attrs = append(attrs, slog.String("authorization", req.Header.Get("Authorization")))
+		if body := logBody(req); body != "" {
+			attrs = append(attrs, slog.String("body", body))
+		}
+	}
+	t.Logger.LogAttrs(context.WithoutCancel(ctx), level, "deepl request", attrs...)
+}
+
+// logQuery returns the encoded query, with sensitive values redacted unless logging is unredacted.
+func (t *Transport) logQuery(query url.Values) string {
+	if !t.LogUnredacted {
+		for _, param := range sensitiveParams {
+			if query.Has(param) {
+				query.Set(param, redacted)
+			}
+		}
+	}
+	return query.Encode()
+}
+
+// logBody returns the beginning of a form encoded request body. Other bodies, e.g. uploaded documents, are not logged.
+func logBody(req *http.Request) string {
+	if req.GetBody == nil || !strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	var buf bytes.Buffer
+	io.CopyN(&buf, body, maxLoggedBody)
+	return buf.String()
+}
@@ -0,0 +1,64 @@
+package deepl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/deepltest"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+func TestTransport_Logger(t *testing.T) {
+	for _, unredacted := range []bool{false, true} {
+		server := deepltest.NewServer(deepltest.WithTooManyRequests(1))
+		defer server.Close()
+		var buf bytes.Buffer
+		opts := []func(*types.TranslatorOptions) error{
+			WithServerURL(server.ServerURL()),
+			WithRetryBackoff(time.Millisecond, time.Millisecond, 1, 0),
+			WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		}
+		if unredacted {
+			opts = append(opts, WithUnredactedLogging())
+		}
+		translator, err := NewTranslator("secret_key", opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := translator.TranslateText(context.Background(), []string{"proton beam"}, consts.SourceLangEnglish, consts.TargetLangGerman); err != nil {
+			t.Fatal(err)
+		}
+
+		var records []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var record map[string]any
+			if err := json.Unmarshal([]byte(line), &record); err != nil {
+				t.Fatal(err)
+			}
+			records = append(records, record)
+		}
+		if len(records) != 2 {
+			t.Fatalf("got %d log records, want 2: %s", len(records), buf.String())
+		}
+		if records[0]["level"] != "WARN" || records[0]["status"] != 429.0 || records[0]["retry"] != true || records[0]["attempt"] != 1.0 {
+			t.Errorf("unexpected record of the throttled attempt %v", records[0])
+		}
+		if records[1]["level"] != "DEBUG" || records[1]["status"] != 200.0 || records[1]["attempt"] != 2.0 || records[1]["path"] != "/v2/translate" {
+			t.Errorf("unexpected record of the successful attempt %v", records[1])
+		}
+		if records[0]["request_id"] == nil || records[0]["request_id"] != records[1]["request_id"] {
+			t.Error("attempts of a request must share the request id")
+		}
+		for _, value := range []string{"secret_key", "proton"} {
+			if logged := strings.Contains(buf.String(), value); logged != unredacted {
+				t.Errorf("%q logged: %v, want %v", value, logged, unredacted)
+			}
+		}
+	}
+}
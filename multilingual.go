@@ -0,0 +1,248 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// GlossaryDictionary holds the entries of a multilingual glossary for one language pair.
+type GlossaryDictionary struct {
+	SourceLang consts.SourceLang
+	TargetLang consts.TargetLang
+	Entries    GlossaryEntries
+}
+
+// dictionaryRequest is a dictionary as sent to the v3 glossary API.
+type dictionaryRequest struct {
+	SourceLang    string `json:"source_lang"`
+	TargetLang    string `json:"target_lang"`
+	Entries       string `json:"entries"`
+	EntriesFormat string `json:"entries_format"`
+}
+
+// dictionaryRequests validates the dictionaries and converts them to their request format.
+func (d *Translator) dictionaryRequests(ctx context.Context, dictionaries []GlossaryDictionary) ([]dictionaryRequest, error) {
+	catalog := d.languageCatalog(ctx)
+	result := make([]dictionaryRequest, len(dictionaries))
+	for i, dictionary := range dictionaries {
+		if err := dictionary.Entries.Validate(); err != nil {
+			return nil, fmt.Errorf("dictionary %s-%s: %w", dictionary.SourceLang, dictionary.TargetLang, err)
+		}
+		if catalog != nil {
+			if err := catalog.ValidateGlossary(dictionary.SourceLang, dictionary.TargetLang); err != nil {
+				return nil, err
+			}
+		}
+		result[i] = dictionaryRequest{
+			SourceLang:    string(dictionary.SourceLang),
+			TargetLang:    string(dictionary.TargetLang),
+			Entries:       dictionary.Entries.ToTSV(),
+			EntriesFormat: "tsv",
+		}
+	}
+	return result, nil
+}
+
+// CreateMultilingualGlossary creates a glossary with a dictionary for each language pair.
+// Its id can be used with WithGlossary and WithDocumentGlossary like the id of any other glossary.
+func (d *Translator) CreateMultilingualGlossary(
+	ctx context.Context,
+	name string,
+	dictionaries []GlossaryDictionary,
+) (response types.MultilingualGlossary, err error) {
+	ctx, end := d.observe(ctx, consts.OperationCreateMultilingualGlossary)
+	defer end(&err)
+	if len(dictionaries) == 0 {
+		return response, errors.New("no dictionaries provided")
+	}
+	body, err := d.dictionaryRequests(ctx, dictionaries)
+	if err != nil {
+		return response, err
+	}
+	err = requests.
+		URL("/v3/glossaries").
+		Method("POST").
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		BodyJSON(map[string]any{"name": name, "dictionaries": body}).
+		ToJSON(&response).
+		Fetch(ctx)
+	if err != nil {
+		return response, contextError(ctx, err)
+	}
+	return response, nil
+}
+
+// GetMultilingualGlossaries returns all glossaries of the account, including those created with CreateGlossary.
+func (d *Translator) GetMultilingualGlossaries(ctx context.Context) (_ []types.MultilingualGlossary, err error) {
+	ctx, end := d.observe(ctx, consts.OperationGetMultilingualGlossaries)
+	defer end(&err)
+	var response types.MultilingualGlossaries
+	err = requests.
+		URL("/v3/glossaries").
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		ToJSON(&response).
+		Fetch(ctx)
+	if err != nil {
+		return response.Glossaries, contextError(ctx, err)
+	}
+	return response.Glossaries, nil
+}
+
+// GetMultilingualGlossary returns the details of a glossary and its dictionaries.
+func (d *Translator) GetMultilingualGlossary(ctx context.Context, id string) (_ types.MultilingualGlossary, err error) {
+	ctx, end := d.observe(ctx, consts.OperationGetMultilingualGlossary)
+	defer end(&err)
+	var response types.MultilingualGlossary
+	err = requests.
+		URL(fmt.Sprintf("/v3/glossaries/%s", id)).
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		ToJSON(&response).
+		Fetch(ctx)
+	if err != nil {
+		return response, contextError(ctx, err)
+	}
+	return response, nil
+}
+
+// UpdateMultilingualGlossary adds the entries of the dictionaries to a glossary, overwriting entries with
+// the same source term. Dictionaries of new language pairs are added. A non-empty name renames the glossary.
+func (d *Translator) UpdateMultilingualGlossary(
+	ctx context.Context,
+	id string,
+	name string,
+	dictionaries []GlossaryDictionary,
+) (response types.MultilingualGlossary, err error) {
+	ctx, end := d.observe(ctx, consts.OperationUpdateMultilingualGlossary)
+	defer end(&err)
+	body, err := d.dictionaryRequests(ctx, dictionaries)
+	if err != nil {
+		return response, err
+	}
+	update := map[string]any{}
+	if name != "" {
+		update["name"] = name
+	}
+	if len(body) > 0 {
+		update["dictionaries"] = body
+	}
+	err = requests.
+		URL(fmt.Sprintf("/v3/glossaries/%s", id)).
+		Patch().
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		BodyJSON(update).
+		ToJSON(&response).
+		Fetch(ctx)
+	if err != nil {
+		return response, contextError(ctx, err)
+	}
+	return response, nil
+}
+
+// DeleteMultilingualGlossary deletes a glossary with all its dictionaries.
+func (d *Translator) DeleteMultilingualGlossary(ctx context.Context, id string) (err error) {
+	ctx, end := d.observe(ctx, consts.OperationDeleteMultilingualGlossary)
+	defer end(&err)
+	err = requests.
+		URL(fmt.Sprintf("/v3/glossaries/%s", id)).
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		Delete().
+		Fetch(ctx)
+	if err != nil {
+		return contextError(ctx, err)
+	}
+	return nil
+}
+
+// GetDictionaryEntries returns the entries of the dictionary of a glossary for a language pair.
+func (d *Translator) GetDictionaryEntries(
+	ctx context.Context,
+	id string,
+	source consts.SourceLang,
+	target consts.TargetLang,
+) (_ GlossaryEntries, err error) {
+	ctx, end := d.observe(ctx, consts.OperationGetDictionaryEntries)
+	defer end(&err)
+	var response struct {
+		Dictionaries []dictionaryRequest `json:"dictionaries"`
+	}
+	err = requests.
+		URL(fmt.Sprintf("/v3/glossaries/%s/entries", id)).
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		Param("source_lang", string(source)).
+		Param("target_lang", string(target)).
+		ToJSON(&response).
+		Fetch(ctx)
+	if err != nil {
+		return GlossaryEntries{}, contextError(ctx, err)
+	}
+	if len(response.Dictionaries) != 1 {
+		return GlossaryEntries{}, fmt.Errorf("deepl: got %d dictionaries for %s-%s", len(response.Dictionaries), source, target)
+	}
+	dictionary := response.Dictionaries[0]
+	var entries *GlossaryEntries
+	if dictionary.EntriesFormat == "csv" {
+		entries, err = NewGlossaryEntriesFromCSV(dictionary.Entries)
+	} else {
+		entries, err = NewGlossaryEntries(dictionary.Entries)
+	}
+	if err != nil {
+		return GlossaryEntries{}, err
+	}
+	return *entries, nil
+}
+
+// ReplaceDictionary replaces all entries of the dictionary of a glossary for the language pair of dictionary.
+// The dictionary is added if the glossary has none for the language pair.
+func (d *Translator) ReplaceDictionary(
+	ctx context.Context,
+	id string,
+	dictionary GlossaryDictionary,
+) (response types.GlossaryDictionaryInfo, err error) {
+	ctx, end := d.observe(ctx, consts.OperationReplaceDictionary)
+	defer end(&err)
+	body, err := d.dictionaryRequests(ctx, []GlossaryDictionary{dictionary})
+	if err != nil {
+		return response, err
+	}
+	err = requests.
+		URL(fmt.Sprintf("/v3/glossaries/%s/dictionaries", id)).
+		Put().
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		BodyJSON(body[0]).
+		ToJSON(&response).
+		Fetch(ctx)
+	if err != nil {
+		return response, contextError(ctx, err)
+	}
+	return response, nil
+}
+
+// DeleteDictionary deletes the dictionary of a glossary for a language pair.
+func (d *Translator) DeleteDictionary(ctx context.Context, id string, source consts.SourceLang, target consts.TargetLang) (err error) {
+	ctx, end := d.observe(ctx, consts.OperationDeleteDictionary)
+	defer end(&err)
+	err = requests.
+		URL(fmt.Sprintf("/v3/glossaries/%s/dictionaries", id)).
+		Client(d.HttpClient).
+		AddValidator(checkStatusCode).
+		Param("source_lang", string(source)).
+		Param("target_lang", string(target)).
+		Delete().
+		Fetch(ctx)
+	if err != nil {
+		return contextError(ctx, err)
+	}
+	return nil
+}
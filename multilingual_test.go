@@ -0,0 +1,133 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/deepltest"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+func mustEntries(t *testing.T, entries map[string]string) GlossaryEntries {
+	t.Helper()
+	g, err := NewGlossaryEntries(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *g
+}
+
+func TestTranslator_MultilingualGlossary(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+	translator, err := NewTranslator("auth_key", WithServerURL(server.ServerURL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	glossary, err := translator.CreateMultilingualGlossary(ctx, "physics", []GlossaryDictionary{
+		{consts.SourceLangEnglish, consts.TargetLangGerman, mustEntries(t, map[string]string{"beam": "Strahl"})},
+		{consts.SourceLangEnglish, consts.TargetLangFrench, mustEntries(t, map[string]string{"beam": "faisceau"})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []types.GlossaryDictionaryInfo{{SourceLang: "en", TargetLang: "de", EntryCount: 1}, {SourceLang: "en", TargetLang: "fr", EntryCount: 1}}
+	if diff := cmp.Diff(want, glossary.Dictionaries); diff != "" {
+		t.Errorf("unexpected dictionaries (-want +got):\n%s", diff)
+	}
+
+	for target, want := range map[consts.TargetLang]string{consts.TargetLangGerman: "Strahl", consts.TargetLangFrench: "faisceau"} {
+		translations, err := translator.TranslateText(ctx, []string{"beam"}, consts.SourceLangEnglish, target, WithGlossary(glossary.GlossaryID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if translations[0].Text != want {
+			t.Errorf("%s: got %q, want %q", target, translations[0].Text, want)
+		}
+	}
+
+	_, err = translator.UpdateMultilingualGlossary(ctx, glossary.GlossaryID, "", []GlossaryDictionary{
+		{consts.SourceLangEnglish, consts.TargetLangGerman, mustEntries(t, map[string]string{"proton": "Proton"})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := translator.GetDictionaryEntries(ctx, glossary.GlossaryID, consts.SourceLangEnglish, consts.TargetLangGerman)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := entries.ToTSV(), "beam\tStrahl\nproton\tProton"; got != want {
+		t.Errorf("got entries %q, want %q", got, want)
+	}
+
+	info, err := translator.ReplaceDictionary(ctx, glossary.GlossaryID, GlossaryDictionary{
+		consts.SourceLangEnglish, consts.TargetLangGerman, mustEntries(t, map[string]string{"ion": "Ion"}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.EntryCount != 1 {
+		t.Errorf("got %d entries after replace, want 1", info.EntryCount)
+	}
+	if err := translator.DeleteDictionary(ctx, glossary.GlossaryID, consts.SourceLangEnglish, consts.TargetLangFrench); err != nil {
+		t.Fatal(err)
+	}
+	got, err := translator.GetMultilingualGlossary(ctx, glossary.GlossaryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]types.GlossaryDictionaryInfo{{SourceLang: "en", TargetLang: "de", EntryCount: 1}}, got.Dictionaries); diff != "" {
+		t.Errorf("unexpected dictionaries (-want +got):\n%s", diff)
+	}
+
+	if err := translator.DeleteMultilingualGlossary(ctx, glossary.GlossaryID); err != nil {
+		t.Fatal(err)
+	}
+	var notFound *GlossaryNotFoundError
+	if _, err := translator.GetMultilingualGlossary(ctx, glossary.GlossaryID); !errors.As(err, &notFound) {
+		t.Errorf("got %v, want GlossaryNotFoundError", err)
+	}
+}
+
+func TestTranslator_MultilingualGlossaryList(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+	translator, err := NewTranslator("auth_key", WithServerURL(server.ServerURL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err := translator.CreateGlossary(ctx, "single", consts.SourceLangEnglish, consts.TargetLangGerman, mustEntries(t, map[string]string{"beam": "Strahl"})); err != nil {
+		t.Fatal(err)
+	}
+	_, err = translator.CreateMultilingualGlossary(ctx, "multi", []GlossaryDictionary{
+		{consts.SourceLangEnglish, consts.TargetLangGerman, mustEntries(t, map[string]string{"beam": "Strahl"})},
+		{consts.SourceLangGerman, consts.TargetLangEnglishUS, mustEntries(t, map[string]string{"Strahl": "beam"})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	multilingual, err := translator.GetMultilingualGlossaries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(multilingual) != 2 || multilingual[0].Name != "single" || multilingual[1].Name != "multi" {
+		t.Errorf("got multilingual glossaries %+v, want single and multi", multilingual)
+	}
+	glossaries, err := translator.GetGlossaries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(glossaries) != 1 || glossaries[0].Name != "single" {
+		t.Errorf("got glossaries %+v, want only single", glossaries)
+	}
+	if _, err := translator.CreateMultilingualGlossary(ctx, "invalid", []GlossaryDictionary{
+		{consts.SourceLangEnglish, consts.TargetLangGerman, GlossaryEntries{}},
+	}); !errors.Is(err, ErrInvalidGlossaryEntries) {
+		t.Errorf("got %v, want ErrInvalidGlossaryEntries", err)
+	}
+}
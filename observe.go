@@ -0,0 +1,34 @@
+package deepl
+
+import (
+	"context"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// WithObserver notifies observer about every operation and request of the Translator,
+// e.g. to record traces and metrics with the deeplotel module.
+func WithObserver(observer types.Observer) func(*types.TranslatorOptions) error {
+	return func(options *types.TranslatorOptions) error {
+		options.Observer = observer
+		return nil
+	}
+}
+
+// observe starts op at the Observer of the Translator. The returned function must be deferred
+// with a pointer to the error of the operation.
+func (d *Translator) observe(ctx context.Context, op consts.Operation) (context.Context, func(*error)) {
+	if d.options.Observer == nil {
+		return ctx, func(*error) {}
+	}
+	ctx, end := d.options.Observer.StartOperation(ctx, op)
+	return ctx, func(err *error) { end(*err) }
+}
+
+// observeCharacters reports n translated characters to the Observer of the Translator.
+func (d *Translator) observeCharacters(ctx context.Context, n int) {
+	if d.options.Observer != nil {
+		d.options.Observer.TranslatedCharacters(ctx, n)
+	}
+}
@@ -0,0 +1,76 @@
+package deepl
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/deepltest"
+)
+
+// recordingObserver records all notifications as strings.
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []string
+}
+
+type observerKey struct{}
+
+func (o *recordingObserver) record(event string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, event)
+}
+
+func (o *recordingObserver) StartOperation(ctx context.Context, op consts.Operation) (context.Context, func(error)) {
+	o.record("start " + string(op))
+	return context.WithValue(ctx, observerKey{}, op), func(err error) {
+		o.record("end " + string(op))
+	}
+}
+
+func (o *recordingObserver) StartRequest(ctx context.Context, req *http.Request, attempt int) (context.Context, func(*http.Response, error)) {
+	parent, _ := ctx.Value(observerKey{}).(consts.Operation)
+	return ctx, func(res *http.Response, err error) {
+		o.record(string(parent) + " " + req.URL.Path + " " + http.StatusText(res.StatusCode))
+	}
+}
+
+func (o *recordingObserver) TranslatedCharacters(ctx context.Context, n int) {
+	o.record("translated characters")
+}
+
+func (o *recordingObserver) BilledCharacters(ctx context.Context, n int) {
+	o.record("billed characters")
+}
+
+func TestTranslator_Observer(t *testing.T) {
+	server := deepltest.NewServer(deepltest.WithTooManyRequests(1))
+	defer server.Close()
+	observer := &recordingObserver{}
+	translator, err := NewTranslator("auth_key",
+		WithServerURL(server.ServerURL()),
+		WithRetryBackoff(time.Millisecond, time.Millisecond, 1, 0),
+		WithObserver(observer),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := translator.TranslateText(context.Background(), []string{"proton beam"}, consts.SourceLangEnglish, consts.TargetLangGerman); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"start translate_text",
+		"translate_text /v2/translate Too Many Requests",
+		"translate_text /v2/translate OK",
+		"translated characters",
+		"end translate_text",
+	}
+	if diff := cmp.Diff(want, observer.events); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,405 @@
+package deepl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// WithTextTranslateOptions replaces all text translation options at once.
+// Set fields are validated like the options setting them one by one, empty fields leave them unset.
+func WithTextTranslateOptions(options types.TextTranslateOptions) func(*types.TextTranslateOptions) error {
+	return func(opts *types.TextTranslateOptions) error {
+		if err := validateFormality(options.Formality); err != nil {
+			return err
+		}
+		if options.SplitSentences != "" {
+			if err := validateSplitSentences(options.SplitSentences); err != nil {
+				return err
+			}
+		}
+		if options.TagHandling != "" {
+			if err := validateTagHandling(options.TagHandling); err != nil {
+				return err
+			}
+		}
+		for _, param := range []struct{ name, value string }{
+			{"preserve formatting", options.PreserveFormatting},
+			{"outline detection", options.OutlineDetection},
+		} {
+			if param.value != "" && param.value != "0" && param.value != "1" {
+				return fmt.Errorf("%w: %s must be \"0\" or \"1\", got %q", ErrInvalidOption, param.name, param.value)
+			}
+		}
+		for _, tags := range []string{options.NonSplittingTags, options.SplittingTags, options.IgnoreTags} {
+			if tags == "" {
+				continue
+			}
+			if _, err := joinTags(strings.Split(tags, ",")); err != nil {
+				return err
+			}
+		}
+		*opts = options
+		return nil
+	}
+}
+
+// WithFormality sets whether the translation should lean towards formal or informal language.
+func WithFormality(formality consts.Formality) func(*types.TextTranslateOptions) error {
+	return func(opts *types.TextTranslateOptions) error {
+		if err := validateFormality(formality); err != nil {
+			return err
+		}
+		opts.Formality = formality
+		return nil
+	}
+}
+
+// WithGlossary sets the glossary used for the translation.
+func WithGlossary(glossaryID string) func(*types.TextTranslateOptions) error {
+	return func(opts *types.TextTranslateOptions) error {
+		if glossaryID == "" {
+			return fmt.Errorf("%w: glossary id must be a non-empty string", ErrInvalidOption)
+		}
+		opts.GlossaryID = glossaryID
+		return nil
+	}
+}
+
+// WithSplitSentences sets how the input is split into sentences.
+// Possible values: "0", "1", "nonewlines".
+func WithSplitSentences(splitSentences string) func(*types.TextTranslateOptions) error {
+	return func(opts *types.TextTranslateOptions) error {
+		if err := validateSplitSentences(splitSentences); err != nil {
+			return err
+		}
+		opts.SplitSentences = splitSentences
+		return nil
+	}
+}
+
+// WithPreserveFormatting sets whether the translation should respect the original formatting.
+func WithPreserveFormatting(preserve bool) func(*types.TextTranslateOptions) error {
+	return func(opts *types.TextTranslateOptions) error {
+		opts.PreserveFormatting = boolParam(preserve)
+		return nil
+	}
+}
+
+// WithTagHandling sets which kind of tags should be handled. Possible values: "xml", "html".
+func WithTagHandling(tagHandling string) func(*types.TextTranslateOptions) error {
+	return func(opts *types.TextTranslateOptions) error {
+		if err := validateTagHandling(tagHandling); err != nil {
+			return err
+		}
+		opts.TagHandling = tagHandling
+		return nil
+	}
+}
+
+// WithOutlineDetection sets whether the automatic detection of the XML structure is used.
+func WithOutlineDetection(detect bool) func(*types.TextTranslateOptions) error {
+	return func(opts *types.TextTranslateOptions) error {
+		opts.OutlineDetection = boolParam(detect)
+		return nil
+	}
+}
+
+// WithNonSplittingTags sets the XML tags which never split sentences.
+func WithNonSplittingTags(tags ...string) func(*types.TextTranslateOptions) error {
+	return func(opts *types.TextTranslateOptions) error {
+		joined, err := joinTags(tags)
+		if err != nil {
+			return err
+		}
+		opts.NonSplittingTags = joined
+		return nil
+	}
+}
+
+// WithSplittingTags sets the XML tags which always split sentences.
+func WithSplittingTags(tags ...string) func(*types.TextTranslateOptions) error {
+	return func(opts *types.TextTranslateOptions) error {
+		joined, err := joinTags(tags)
+		if err != nil {
+			return err
+		}
+		opts.SplittingTags = joined
+		return nil
+	}
+}
+
+// WithIgnoreTags sets the XML tags whose content is not translated.
+func WithIgnoreTags(tags ...string) func(*types.TextTranslateOptions) error {
+	return func(opts *types.TextTranslateOptions) error {
+		joined, err := joinTags(tags)
+		if err != nil {
+			return err
+		}
+		opts.IgnoreTags = joined
+		return nil
+	}
+}
+
+// WithContext sets additional context that influences the translation but is not translated itself.
+func WithContext(text string) func(*types.TextTranslateOptions) error {
+	return func(opts *types.TextTranslateOptions) error {
+		if text == "" {
+			return fmt.Errorf("%w: context must be a non-empty string", ErrInvalidOption)
+		}
+		opts.Context = text
+		return nil
+	}
+}
+
+// WithDocumentTranslateOptions replaces all document translation options at once.
+// Set fields are validated and normalised as by their own options, e.g. WithOutputFormat.
+func WithDocumentTranslateOptions(options types.DocumentTranslateOptions) func(*types.DocumentTranslateOptions) error {
+	return func(opts *types.DocumentTranslateOptions) error {
+		if err := validateFormality(options.Formality); err != nil {
+			return err
+		}
+		var set []func(*types.DocumentTranslateOptions) error
+		if options.FileName != "" {
+			set = append(set, WithFileName(options.FileName))
+		}
+		if options.GlossaryID != "" {
+			set = append(set, WithDocumentGlossary(options.GlossaryID))
+		}
+		if options.OutputFormat != "" {
+			set = append(set, WithOutputFormat(options.OutputFormat))
+		}
+		if options.MaxPollWait != 0 {
+			set = append(set, WithDocumentMaxPollWait(options.MaxPollWait))
+		}
+		for _, opt := range set {
+			if err := opt(&options); err != nil {
+				return err
+			}
+		}
+		if options.OutputFile == nil {
+			options.OutputFile = opts.OutputFile
+		}
+		*opts = options
+		return nil
+	}
+}
+
+// WithDocumentFormality sets whether the document translation should lean towards formal or informal language.
+func WithDocumentFormality(formality consts.Formality) func(*types.DocumentTranslateOptions) error {
+	return func(opts *types.DocumentTranslateOptions) error {
+		if err := validateFormality(formality); err != nil {
+			return err
+		}
+		opts.Formality = formality
+		return nil
+	}
+}
+
+// WithDocumentGlossary sets the glossary used for the document translation.
+func WithDocumentGlossary(glossaryID string) func(*types.DocumentTranslateOptions) error {
+	return func(opts *types.DocumentTranslateOptions) error {
+		if strings.TrimSpace(glossaryID) == "" {
+			return fmt.Errorf("%w: glossary id must be a non-empty string", ErrInvalidOption)
+		}
+		opts.GlossaryID = glossaryID
+		return nil
+	}
+}
+
+// WithFileName sets the file name of the uploaded document. The extension determines the document type.
+func WithFileName(fileName string) func(*types.DocumentTranslateOptions) error {
+	return func(opts *types.DocumentTranslateOptions) error {
+		if strings.TrimSpace(fileName) == "" {
+			return fmt.Errorf("%w: file name must be a non-empty string", ErrInvalidOption)
+		}
+		opts.FileName = fileName
+		return nil
+	}
+}
+
+// WithOutputFile sets the writer the translated document is written to.
+func WithOutputFile(w io.Writer) func(*types.DocumentTranslateOptions) error {
+	return func(opts *types.DocumentTranslateOptions) error {
+		if w == nil {
+			return fmt.Errorf("%w: output file must not be nil", ErrInvalidOption)
+		}
+		opts.OutputFile = w
+		return nil
+	}
+}
+
+// WithOutputFormat sets the file format of the translated document, e.g. "pdf" or "docx".
+func WithOutputFormat(format string) func(*types.DocumentTranslateOptions) error {
+	return func(opts *types.DocumentTranslateOptions) error {
+		format = strings.ToLower(strings.TrimPrefix(format, "."))
+		if format == "" || strings.ContainsAny(format, "./\\ ") {
+			return fmt.Errorf("%w: invalid output format %q", ErrInvalidOption, format)
+		}
+		opts.OutputFormat = format
+		return nil
+	}
+}
+
+// WithDocumentPollStrategy overrides the poll strategy of the Translator for this document.
+func WithDocumentPollStrategy(strategy types.PollStrategy) func(*types.DocumentTranslateOptions) error {
+	return func(opts *types.DocumentTranslateOptions) error {
+		if strategy == nil {
+			return fmt.Errorf("%w: poll strategy must not be nil", ErrInvalidOption)
+		}
+		opts.PollStrategy = strategy
+		return nil
+	}
+}
+
+// WithDocumentMaxPollWait overrides the maximum time to wait for this document translation to finish.
+func WithDocumentMaxPollWait(maxWait time.Duration) func(*types.DocumentTranslateOptions) error {
+	return func(opts *types.DocumentTranslateOptions) error {
+		if maxWait <= 0 {
+			return fmt.Errorf("%w: max poll wait must be positive", ErrInvalidOption)
+		}
+		opts.MaxPollWait = maxWait
+		return nil
+	}
+}
+
+// WithStatusHook sets a function that is called with every status received while waiting for the translation.
+func WithStatusHook(hook func(types.DocumentStatus)) func(*types.DocumentTranslateOptions) error {
+	return func(opts *types.DocumentTranslateOptions) error {
+		opts.OnStatus = hook
+		return nil
+	}
+}
+
+// WithProgress sets a function that receives progress events of the document translation.
+func WithProgress(fn func(types.DocumentEvent)) func(*types.DocumentTranslateOptions) error {
+	return func(opts *types.DocumentTranslateOptions) error {
+		if fn == nil {
+			return fmt.Errorf("%w: progress function must not be nil", ErrInvalidOption)
+		}
+		opts.OnEvent = fn
+		return nil
+	}
+}
+
+// WithProgressChannel sends progress events of the document translation to ch.
+// Sends never block the translation: events are dropped while the channel is full,
+// so use a buffered channel to receive all of them.
+func WithProgressChannel(ch chan<- types.DocumentEvent) func(*types.DocumentTranslateOptions) error {
+	return func(opts *types.DocumentTranslateOptions) error {
+		if ch == nil {
+			return fmt.Errorf("%w: progress channel must not be nil", ErrInvalidOption)
+		}
+		opts.OnEvent = func(event types.DocumentEvent) {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+		return nil
+	}
+}
+
+// WithEntriesFormat sets the format the entries of a glossary are sent in, "tsv" or "csv".
+func WithEntriesFormat(format string) func(*types.GlossaryOptions) error {
+	return func(opts *types.GlossaryOptions) error {
+		if format != "tsv" && format != "csv" {
+			return fmt.Errorf("%w: entries format must be \"tsv\" or \"csv\", got %q", ErrInvalidOption, format)
+		}
+		opts.EntriesFormat = format
+		return nil
+	}
+}
+
+// validateFormality returns an error if formality is not one of the known values.
+// The empty value leaves the formality unset.
+func validateFormality(formality consts.Formality) error {
+	switch formality {
+	case "", consts.Default, consts.More, consts.Less, consts.PreferMore, consts.PreferLess:
+		return nil
+	}
+	return fmt.Errorf("%w: unknown formality %q", ErrInvalidOption, formality)
+}
+
+// validateSplitSentences returns an error if splitSentences is not one of the values of the API.
+func validateSplitSentences(splitSentences string) error {
+	switch splitSentences {
+	case "0", "1", "nonewlines":
+		return nil
+	}
+	return fmt.Errorf("%w: split sentences must be one of \"0\", \"1\", \"nonewlines\", got %q", ErrInvalidOption, splitSentences)
+}
+
+// validateTagHandling returns an error if tagHandling is neither "xml" nor "html".
+func validateTagHandling(tagHandling string) error {
+	if tagHandling != "xml" && tagHandling != "html" {
+		return fmt.Errorf("%w: tag handling must be \"xml\" or \"html\", got %q", ErrInvalidOption, tagHandling)
+	}
+	return nil
+}
+
+// joinTags joins XML tags to the comma-separated list expected by the API.
+func joinTags(tags []string) (string, error) {
+	if len(tags) == 0 {
+		return "", fmt.Errorf("%w: at least one tag is required", ErrInvalidOption)
+	}
+	for _, tag := range tags {
+		if tag == "" || strings.ContainsAny(tag, ", <>") {
+			return "", fmt.Errorf("%w: invalid tag %q", ErrInvalidOption, tag)
+		}
+	}
+	return strings.Join(tags, ","), nil
+}
+
+// boolParam converts a bool to the "0"/"1" representation of the API.
+func boolParam(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// WithConcurrency sets the maximum number of concurrent requests of a batch translation.
+func WithConcurrency(n int) func(*types.BatchTranslateOptions) error {
+	return func(opts *types.BatchTranslateOptions) error {
+		if n <= 0 {
+			return fmt.Errorf("%w: concurrency must be positive", ErrInvalidOption)
+		}
+		opts.Concurrency = n
+		return nil
+	}
+}
+
+// WithOutputDir writes the translated documents of a batch to dir.
+func WithOutputDir(dir string) func(*types.BatchTranslateOptions) error {
+	return func(opts *types.BatchTranslateOptions) error {
+		if dir == "" {
+			return fmt.Errorf("%w: output directory must be a non-empty string", ErrInvalidOption)
+		}
+		opts.OutputDir = dir
+		return nil
+	}
+}
+
+// WithOutput sets the function opening the writer for each translated document of a batch.
+func WithOutput(fn func(types.DocumentInput, consts.TargetLang) (io.WriteCloser, error)) func(*types.BatchTranslateOptions) error {
+	return func(opts *types.BatchTranslateOptions) error {
+		if fn == nil {
+			return fmt.Errorf("%w: output function must not be nil", ErrInvalidOption)
+		}
+		opts.Output = fn
+		return nil
+	}
+}
+
+// WithBatchDocumentOptions sets options applied to every document of a batch.
+func WithBatchDocumentOptions(opts ...func(*types.DocumentTranslateOptions) error) func(*types.BatchTranslateOptions) error {
+	return func(options *types.BatchTranslateOptions) error {
+		options.DocumentOptions = append(options.DocumentOptions, opts...)
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+func TestTranslator_TextTranslateOptions(t *testing.T) {
+	var query map[string][]string
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		query = r.Form
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"translations":[{"detected_source_language":"EN","text":"Protonenstrahl"}]}`))
+	})
+	_, err := translator.TranslateText(context.Background(), []string{"proton beam"}, consts.SourceLangEnglish, consts.TargetLangGerman,
+		WithFormality(consts.More),
+		WithGlossary("glossary"),
+		WithTagHandling("xml"),
+		WithIgnoreTags("code", "pre"),
+		WithSplitSentences("nonewlines"),
+		WithContext("physics"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"formality":       "more",
+		"glossary_id":     "glossary",
+		"tag_handling":    "xml",
+		"ignore_tags":     "code,pre",
+		"split_sentences": "nonewlines",
+		"context":         "physics",
+	}
+	for k, v := range want {
+		if got := query[k]; len(got) != 1 || got[0] != v {
+			t.Errorf("param %s: got %v, want %s", k, got, v)
+		}
+	}
+}
+
+func TestTranslator_InvalidOption(t *testing.T) {
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected for invalid options")
+	})
+	opts := []func(*types.TextTranslateOptions) error{
+		WithFormality("very"),
+		WithTagHandling("json"),
+		WithSplitSentences("2"),
+		WithIgnoreTags(),
+		WithGlossary(""),
+		WithTextTranslateOptions(types.TextTranslateOptions{TagHandling: "json"}),
+		WithTextTranslateOptions(types.TextTranslateOptions{SplitSentences: "2"}),
+		WithTextTranslateOptions(types.TextTranslateOptions{PreserveFormatting: "yes"}),
+		WithTextTranslateOptions(types.TextTranslateOptions{IgnoreTags: "a,<b>"}),
+	}
+	for _, opt := range opts {
+		_, err := translator.TranslateText(context.Background(), []string{"proton beam"}, consts.SourceLangEnglish, consts.TargetLangGerman, opt)
+		if !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("got %v, want ErrInvalidOption", err)
+		}
+	}
+	documentOpts := []func(*types.DocumentTranslateOptions) error{
+		WithOutputFormat("pdf/x"),
+		WithDocumentTranslateOptions(types.DocumentTranslateOptions{OutputFormat: "pdf/x"}),
+		WithDocumentTranslateOptions(types.DocumentTranslateOptions{GlossaryID: " "}),
+		WithDocumentTranslateOptions(types.DocumentTranslateOptions{FileName: " "}),
+		WithDocumentTranslateOptions(types.DocumentTranslateOptions{MaxPollWait: -time.Second}),
+	}
+	for _, opt := range documentOpts {
+		_, err := translator.TranslateDocument(context.Background(), consts.SourceLangEnglish, consts.TargetLangGerman, strings.NewReader("proton beam"), io.Discard, opt)
+		if !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("got %v, want ErrInvalidOption", err)
+		}
+	}
+	var documentOptions types.DocumentTranslateOptions
+	if err := WithDocumentTranslateOptions(types.DocumentTranslateOptions{OutputFormat: ".PDF"})(&documentOptions); err != nil {
+		t.Fatal(err)
+	}
+	if documentOptions.OutputFormat != "pdf" {
+		t.Errorf("got output format %q, want pdf", documentOptions.OutputFormat)
+	}
+}
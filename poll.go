@@ -0,0 +1,98 @@
+package deepl
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// defaultPollInterval replaces zero or negative poll intervals, so a misconfigured strategy
+// cannot check the status in a tight loop.
+const defaultPollInterval = time.Second
+
+// positiveInterval returns d, or defaultPollInterval if d is not positive.
+func positiveInterval(d time.Duration) time.Duration {
+	if d <= 0 {
+		return defaultPollInterval
+	}
+	return d
+}
+
+// FixedPollStrategy waits the same interval between all status checks.
+type FixedPollStrategy struct {
+	Interval time.Duration
+}
+
+// NewFixedPollStrategy returns a PollStrategy that always waits interval.
+// A zero or negative interval is replaced by one second.
+func NewFixedPollStrategy(interval time.Duration) *FixedPollStrategy {
+	return &FixedPollStrategy{Interval: positiveInterval(interval)}
+}
+
+func (p *FixedPollStrategy) NextInterval(attempt int, status types.DocumentStatus) time.Duration {
+	return positiveInterval(p.Interval)
+}
+
+// ExponentialPollStrategy multiplies the interval with every status check, up to Max.
+// Jitter randomizes each interval by up to the given fraction, e.g. 0.2 for ±20%.
+type ExponentialPollStrategy struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     float64
+}
+
+// NewExponentialPollStrategy returns a PollStrategy with exponential backoff and jitter.
+// A zero or negative initial interval is replaced by one second.
+func NewExponentialPollStrategy(initial, max time.Duration, multiplier, jitter float64) *ExponentialPollStrategy {
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	return &ExponentialPollStrategy{Initial: positiveInterval(initial), Max: max, Multiplier: multiplier, Jitter: jitter}
+}
+
+func (p *ExponentialPollStrategy) NextInterval(attempt int, status types.DocumentStatus) time.Duration {
+	interval := float64(positiveInterval(p.Initial)) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.Max > 0 && interval > float64(p.Max) {
+		interval = float64(p.Max)
+	}
+	return applyJitter(time.Duration(interval), p.Jitter)
+}
+
+// ServerHintPollStrategy waits half of the seconds remaining estimated by DeepL plus one second,
+// bounded by Min and Max.
+type ServerHintPollStrategy struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// NewServerHintPollStrategy returns a PollStrategy based on the seconds remaining reported by DeepL.
+// A Translator uses it with a minimum of one second and a maximum of one minute unless configured otherwise.
+func NewServerHintPollStrategy(min, max time.Duration) *ServerHintPollStrategy {
+	return &ServerHintPollStrategy{Min: min, Max: max}
+}
+
+func (p *ServerHintPollStrategy) NextInterval(attempt int, status types.DocumentStatus) time.Duration {
+	interval := time.Duration(status.SecondsRemaining/2+1) * time.Second
+	if interval < p.Min {
+		interval = p.Min
+	}
+	if p.Max > 0 && interval > p.Max {
+		interval = p.Max
+	}
+	return interval
+}
+
+// applyJitter randomizes d by up to ±jitter of its value.
+func applyJitter(d time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 || d <= 0 {
+		return d
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	delta := (rand.Float64()*2 - 1) * jitter * float64(d)
+	return d + time.Duration(delta)
+}
@@ -0,0 +1,92 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hsedr/deepl-golang/types"
+)
+
+func TestPollStrategy_NextInterval(t *testing.T) {
+	status := types.DocumentStatus{SecondsRemaining: 10}
+	tests := []struct {
+		strategy types.PollStrategy
+		attempt  int
+		want     time.Duration
+	}{
+		{NewFixedPollStrategy(time.Second), 5, time.Second},
+		{NewExponentialPollStrategy(time.Second, 5*time.Second, 2, 0), 1, time.Second},
+		{NewExponentialPollStrategy(time.Second, 5*time.Second, 2, 0), 3, 4 * time.Second},
+		{NewExponentialPollStrategy(time.Second, 5*time.Second, 2, 0), 10, 5 * time.Second},
+		{NewServerHintPollStrategy(time.Second, time.Minute), 1, 6 * time.Second},
+		{NewServerHintPollStrategy(time.Second, 3*time.Second), 1, 3 * time.Second},
+		{NewFixedPollStrategy(0), 1, time.Second},
+		{&FixedPollStrategy{Interval: -time.Second}, 1, time.Second},
+		{NewExponentialPollStrategy(0, 5*time.Second, 2, 0), 2, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := tt.strategy.NextInterval(tt.attempt, status); got != tt.want {
+			t.Errorf("%T attempt %d: got %s, want %s", tt.strategy, tt.attempt, got, tt.want)
+		}
+	}
+	jittered := NewExponentialPollStrategy(time.Second, time.Second, 2, 0.5)
+	for i := 0; i < 100; i++ {
+		if got := jittered.NextInterval(1, status); got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Fatalf("jittered interval out of bounds: %s", got)
+		}
+	}
+}
+
+// pollingServer serves a document translation that is done after the given number of status checks.
+func pollingServer(checksUntilDone int32) http.HandlerFunc {
+	var checks int32
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/result") {
+			w.Write([]byte("Protonenstrahl"))
+			return
+		}
+		if atomic.AddInt32(&checks, 1) < checksUntilDone {
+			w.Write([]byte(`{"document_id":"abc","status":"translating","seconds_remaining":30}`))
+			return
+		}
+		w.Write([]byte(`{"document_id":"abc","status":"done","billed_characters":10}`))
+	}
+}
+
+func TestTranslator_WaitWithPollStrategy(t *testing.T) {
+	translator := makeLocalTranslator(t, pollingServer(3), WithPollStrategy(NewFixedPollStrategy(time.Millisecond)))
+	var statuses []string
+	status, err := translator.WaitUntilDocumentTranslationFinished(context.Background(), types.DocumentHandle{DocumentID: "abc", DocumentKey: "key"},
+		WithStatusHook(func(s types.DocumentStatus) { statuses = append(statuses, s.Status) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Done() {
+		t.Errorf("got status %+v", status)
+	}
+	if strings.Join(statuses, ",") != "translating,translating,done" {
+		t.Errorf("got statuses %v", statuses)
+	}
+}
+
+func TestTranslator_WaitMaxPollWait(t *testing.T) {
+	translator := makeLocalTranslator(t, pollingServer(1000))
+	start := time.Now()
+	_, err := translator.WaitUntilDocumentTranslationFinished(context.Background(), types.DocumentHandle{DocumentID: "abc", DocumentKey: "key"},
+		WithDocumentPollStrategy(NewFixedPollStrategy(10*time.Millisecond)),
+		WithDocumentMaxPollWait(50*time.Millisecond),
+	)
+	if !errors.Is(err, ErrPollTimeout) {
+		t.Errorf("got %v, want ErrPollTimeout", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("max poll wait was not respected")
+	}
+}
@@ -0,0 +1,72 @@
+package deepl
+
+import (
+	"errors"
+	"io"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// emitDocumentEvent passes event to the progress function of the options, if any.
+func emitDocumentEvent(options types.DocumentTranslateOptions, event types.DocumentEvent) {
+	if options.OnEvent != nil {
+		options.OnEvent(event)
+	}
+}
+
+// emitDocumentError emits an error event if *err is set. It is meant to be deferred.
+// Failed translations are already reported by notifyDocumentStatus.
+func emitDocumentError(options types.DocumentTranslateOptions, doc *types.DocumentHandle, err *error) {
+	var translationErr *DocumentTranslationError
+	if *err == nil || errors.As(*err, &translationErr) {
+		return
+	}
+	emitDocumentEvent(options, types.DocumentEvent{Type: consts.DocumentEventError, Document: *doc, Err: *err})
+}
+
+// notifyDocumentStatus passes a status received while polling to the status hook and
+// emits the matching progress event.
+func notifyDocumentStatus(options types.DocumentTranslateOptions, doc types.DocumentHandle, status types.DocumentStatus) {
+	if options.OnStatus != nil {
+		options.OnStatus(status)
+	}
+	event := types.DocumentEvent{
+		Document:         doc,
+		SecondsRemaining: status.SecondsRemaining,
+		BilledCharacters: status.BilledCharacters,
+	}
+	switch {
+	case !status.Ok():
+		event.Type = consts.DocumentEventError
+		event.Err = &DocumentTranslationError{DocumentID: doc.DocumentID, Message: status.ErrorMessage}
+	case status.Done():
+		event.Type = consts.DocumentEventDone
+	case status.Status == string(consts.DocumentStatusQueued):
+		event.Type = consts.DocumentEventQueued
+	default:
+		event.Type = consts.DocumentEventTranslating
+	}
+	emitDocumentEvent(options, event)
+}
+
+// progressWriter emits a downloading event for every chunk written to w.
+type progressWriter struct {
+	w       io.Writer
+	doc     types.DocumentHandle
+	written int64
+	total   int64
+	options types.DocumentTranslateOptions
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	emitDocumentEvent(p.options, types.DocumentEvent{
+		Type:            consts.DocumentEventDownloading,
+		Document:        p.doc,
+		BytesDownloaded: p.written,
+		TotalBytes:      p.total,
+	})
+	return n, err
+}
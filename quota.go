@@ -0,0 +1,151 @@
+package deepl
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// quotaGuard checks calls against the character budget. It combines the usage last read from
+// DeepL with the characters consumed locally since then.
+type quotaGuard struct {
+	options types.QuotaOptions
+
+	mu      sync.Mutex
+	usage   types.Usage
+	fetched time.Time
+	// closed when the running refresh of the usage is done, nil if none is running
+	refreshing chan struct{}
+	// characters billed since the usage was read
+	local int
+	// characters reserved for translations still in flight, kept across refreshes of the usage
+	reserved int
+}
+
+// WithQuotaGuard checks every text and document translation against a character budget before it is sent.
+// Calls exceeding it fail with ErrBudgetExceeded, or only call OnWarning if WarnOnly is set.
+func WithQuotaGuard(options types.QuotaOptions) func(*types.TranslatorOptions) error {
+	return func(o *types.TranslatorOptions) error {
+		if options.SoftLimit < 0 || options.RefreshInterval < 0 {
+			return fmt.Errorf("%w: quota limits must not be negative", ErrInvalidOption)
+		}
+		o.Quota = &options
+		return nil
+	}
+}
+
+// reserveCharacters checks that n more characters fit into the budget and reserves them.
+// Every reservation must be settled with settleCharacters once the translation is done.
+// Documents are checked with n = 0, which only fails once the budget is used up.
+func (d *Translator) reserveCharacters(ctx context.Context, n int) error {
+	q := d.quota
+	if q == nil {
+		return nil
+	}
+	if err := d.refreshUsage(ctx); err != nil {
+		return err
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	limit := q.usage.CharacterLimit
+	if q.options.SoftLimit > 0 && (limit <= 0 || q.options.SoftLimit < limit) {
+		limit = q.options.SoftLimit
+	}
+	used := q.usage.CharacterCount + q.local + q.reserved
+	requested := n
+	if requested == 0 {
+		requested = 1
+	}
+	if limit > 0 && used+requested > limit {
+		warning := types.QuotaWarning{Used: used, Requested: n, Limit: limit}
+		if q.options.OnWarning != nil {
+			q.options.OnWarning(warning)
+		}
+		if !q.options.WarnOnly {
+			return fmt.Errorf("%w: %d of %d characters used, %d requested", ErrBudgetExceeded, used, limit, n)
+		}
+	}
+	q.reserved += n
+	return nil
+}
+
+// refreshUsage reads the usage from DeepL if it was never read or is older than the refresh interval.
+// The usage is fetched without holding the lock, so calls are not serialized behind the request.
+// Only the first read is waited for, later refreshes run while other calls use the previous usage.
+func (d *Translator) refreshUsage(ctx context.Context) error {
+	q := d.quota
+	q.mu.Lock()
+	for q.fetched.IsZero() && q.refreshing != nil {
+		done := q.refreshing
+		q.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		q.mu.Lock()
+	}
+	stale := q.fetched.IsZero() || (q.options.RefreshInterval > 0 && time.Since(q.fetched) >= q.options.RefreshInterval)
+	if !stale || q.refreshing != nil {
+		q.mu.Unlock()
+		return nil
+	}
+	done := make(chan struct{})
+	q.refreshing = done
+	q.mu.Unlock()
+
+	usage, err := d.GetUsage(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.refreshing = nil
+	close(done)
+	if err != nil {
+		return err
+	}
+	q.usage = usage
+	q.fetched = time.Now()
+	q.local = 0
+	return nil
+}
+
+// settleCharacters ends a reservation of n characters of which used were translated.
+func (d *Translator) settleCharacters(n, used int) {
+	if d.quota == nil {
+		return
+	}
+	d.quota.mu.Lock()
+	d.quota.reserved -= n
+	d.quota.local += used
+	d.quota.mu.Unlock()
+}
+
+// recordBilledCharacters counts the characters billed for a finished document translation
+// and reports them to the Observer.
+func (d *Translator) recordBilledCharacters(ctx context.Context, status types.DocumentStatus) {
+	if !status.Done() {
+		return
+	}
+	if d.options.Observer != nil {
+		d.options.Observer.BilledCharacters(ctx, status.BilledCharacters)
+	}
+	if d.quota == nil {
+		return
+	}
+	d.quota.mu.Lock()
+	d.quota.local += status.BilledCharacters
+	d.quota.mu.Unlock()
+}
+
+// countCharacters returns the number of characters of all texts, as counted by DeepL.
+func countCharacters(text []string) int {
+	n := 0
+	for _, t := range text {
+		n += utf8.RuneCountInString(t)
+	}
+	return n
+}
@@ -0,0 +1,115 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/deepltest"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+func TestTranslator_QuotaGuard(t *testing.T) {
+	tests := []struct {
+		name        string
+		serverLimit int
+		options     types.QuotaOptions
+		wantErr     bool
+	}{
+		{"soft limit", 1000, types.QuotaOptions{SoftLimit: 20}, true},
+		{"account limit", 15, types.QuotaOptions{}, true},
+		{"warn only", 1000, types.QuotaOptions{SoftLimit: 20, WarnOnly: true}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := deepltest.NewServer(deepltest.WithCharacterLimit(test.serverLimit))
+			defer server.Close()
+			var warnings []types.QuotaWarning
+			test.options.OnWarning = func(w types.QuotaWarning) { warnings = append(warnings, w) }
+			translator, err := NewTranslator("auth_key", WithServerURL(server.ServerURL()), WithQuotaGuard(test.options))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			text := []string{"proton beam"}
+			if _, err := translator.TranslateText(ctx, text, consts.SourceLangEnglish, consts.TargetLangGerman); err != nil {
+				t.Fatal(err)
+			}
+			_, err = translator.TranslateText(ctx, text, consts.SourceLangEnglish, consts.TargetLangGerman)
+			if got := errors.Is(err, ErrBudgetExceeded); got != test.wantErr {
+				t.Errorf("got error %v, want budget exceeded %v", err, test.wantErr)
+			}
+			if len(warnings) != 1 || warnings[0].Used != 11 || warnings[0].Requested != 11 {
+				t.Errorf("got warnings %+v, want one for 11 used and 11 requested characters", warnings)
+			}
+			wantCount := 22
+			if test.wantErr {
+				wantCount = 11
+			}
+			if got := server.CharacterCount(); got != wantCount {
+				t.Errorf("server translated %d characters, want %d", got, wantCount)
+			}
+		})
+	}
+}
+
+func TestTranslator_QuotaRefreshDoesNotBlock(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+	var slow atomic.Bool
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/usage" && slow.Load() {
+			time.Sleep(500 * time.Millisecond)
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}, WithQuotaGuard(types.QuotaOptions{RefreshInterval: time.Nanosecond}))
+	ctx := context.Background()
+	text := []string{"proton beam"}
+	if _, err := translator.TranslateText(ctx, text, consts.SourceLangEnglish, consts.TargetLangGerman); err != nil {
+		t.Fatal(err)
+	}
+	slow.Store(true)
+	refreshed := make(chan error)
+	go func() {
+		_, err := translator.TranslateText(ctx, text, consts.SourceLangEnglish, consts.TargetLangGerman)
+		refreshed <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	// the refresh runs in the other call, this one uses the previous usage
+	start := time.Now()
+	if _, err := translator.TranslateText(ctx, text, consts.SourceLangEnglish, consts.TargetLangGerman); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Errorf("translation took %v, want it not to wait for the usage refresh", elapsed)
+	}
+	if err := <-refreshed; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTranslator_QuotaKeepsReservationsAcrossRefresh(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+	translator, err := NewTranslator("auth_key", WithServerURL(server.ServerURL()),
+		WithQuotaGuard(types.QuotaOptions{SoftLimit: 15, RefreshInterval: time.Nanosecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := translator.reserveCharacters(ctx, 10); err != nil {
+		t.Fatal(err)
+	}
+	// the usage is refreshed, the reservation in flight still counts
+	if err := translator.reserveCharacters(ctx, 10); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("got %v, want the reservation in flight to be kept", err)
+	}
+	translator.settleCharacters(10, 0)
+	if q := translator.quota; q.local != 0 || q.reserved != 0 {
+		t.Errorf("got %d local and %d reserved characters, want 0", q.local, q.reserved)
+	}
+}
@@ -0,0 +1,154 @@
+package deepl
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// characterCountKey is the context key of the number of characters a request translates.
+type characterCountKey struct{}
+
+// withCharacterCount returns a context telling the Transport that its request translates n characters.
+func withCharacterCount(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, characterCountKey{}, n)
+}
+
+// characterCount returns the number of characters set with withCharacterCount.
+func characterCount(ctx context.Context) int {
+	n, _ := ctx.Value(characterCountKey{}).(int)
+	return n
+}
+
+// tokenBucket is a token bucket rate limiter that lets requests borrow tokens, so
+// requests larger than the burst still pass after waiting for their tokens to refill.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a bucket refilling rate tokens per second, holding at most one second worth of tokens.
+func newTokenBucket(rate float64) *tokenBucket {
+	burst := rate
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait takes n tokens and blocks until they are available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context, n float64) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens -= n
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		// give the tokens back, the request is not sent
+		b.mu.Lock()
+		b.tokens += n
+		b.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// limiter holds the rate limits and the in-flight semaphore of a Transport.
+type limiter struct {
+	requests   *tokenBucket
+	characters *tokenBucket
+	inFlight   chan struct{}
+}
+
+func newLimiter(requestsPerSecond, charactersPerSecond float64, maxInFlight int) *limiter {
+	l := &limiter{}
+	if requestsPerSecond > 0 {
+		l.requests = newTokenBucket(requestsPerSecond)
+	}
+	if charactersPerSecond > 0 {
+		l.characters = newTokenBucket(charactersPerSecond)
+	}
+	if maxInFlight > 0 {
+		l.inFlight = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// acquire waits until the request may be sent. The returned function must be called once the request is done.
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if l.inFlight != nil {
+			<-l.inFlight
+		}
+	}
+	if l.requests != nil {
+		if err := l.requests.wait(ctx, 1); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	if n := characterCount(ctx); l.characters != nil && n > 0 {
+		if err := l.characters.wait(ctx, float64(n)); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// releaseBody calls release once the body of a response is closed, so a request counts as in flight
+// until its response has been read.
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// roundTripLimited sends req once the limits of the Transport allow it.
+func (t *Transport) roundTripLimited(req *http.Request) (*http.Response, error) {
+	t.limiterOnce.Do(func() {
+		t.limiter = newLimiter(t.RequestsPerSecond, t.CharactersPerSecond, t.MaxInFlight)
+	})
+	release, err := t.limiter.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	res, err := t.transport().RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	res.Body = &releaseBody{ReadCloser: res.Body, release: release}
+	return res, nil
+}
@@ -0,0 +1,69 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(100)
+	ctx := context.Background()
+	start := time.Now()
+	// the burst of 100 passes at once, another 50 take half a second
+	for i := 0; i < 150; i++ {
+		if err := b.wait(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond || elapsed > time.Second {
+		t.Errorf("got %v for 150 tokens at 100/s, want about 500ms", elapsed)
+	}
+
+	// requests larger than the burst pass once the missing tokens are refilled
+	b = newTokenBucket(100)
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err := b.wait(ctx, 150); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want to wait for 50 missing tokens", err)
+	}
+	start = time.Now()
+	if err := b.wait(context.Background(), 100); err != nil || time.Since(start) > 50*time.Millisecond {
+		t.Errorf("tokens of a canceled wait were not returned, waited %v", time.Since(start))
+	}
+}
+
+func TestTranslator_MaxInFlight(t *testing.T) {
+	var inFlight, maxInFlight int32
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"character_count": 1}`))
+	}, WithMaxInFlight(2))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := translator.GetUsage(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if maxInFlight != 2 {
+		t.Errorf("got %d requests in flight, want 2", maxInFlight)
+	}
+}
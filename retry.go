@@ -0,0 +1,158 @@
+package deepl
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// DefaultRetryBackoff is the backoff between retries unless configured otherwise, as recommended by DeepL.
+var DefaultRetryBackoff = types.RetryBackoff{
+	Initial:    time.Second,
+	Max:        2 * time.Minute,
+	Multiplier: 1.6,
+	Jitter:     0.23,
+}
+
+// retryRoundTrip sends req and retries it on network errors, 429 and 5xx responses up to t.Retries times.
+// Requests that may have created something on the server, uploading a document or creating
+// a glossary, are only retried on 429, which guarantees they were not processed.
+func (t *Transport) retryRoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	requestID := uuid.New().String()
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+		start := time.Now()
+		res, err := t.observedAttempt(req, attempt+1)
+		retry := attempt < t.Retries && shouldRetry(req, res, err)
+		t.logAttempt(req, requestID, attempt+1, time.Since(start), res, err, retry)
+		if !retry {
+			return res, err
+		}
+		delay := retryDelay(t.Backoff, attempt, res)
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// observedAttempt sends req once and reports the attempt to the Observer of the Transport.
+func (t *Transport) observedAttempt(req *http.Request, attempt int) (*http.Response, error) {
+	if t.Observer == nil {
+		return t.roundTripAttempt(req)
+	}
+	ctx, end := t.Observer.StartRequest(req.Context(), req, attempt)
+	res, err := t.roundTripAttempt(req.WithContext(ctx))
+	end(res, err)
+	return res, err
+}
+
+// roundTripAttempt sends req once, limited to t.AttemptTimeout, or t.TimeOut if no attempt timeout is set.
+func (t *Transport) roundTripAttempt(req *http.Request) (*http.Response, error) {
+	timeout := t.AttemptTimeout
+	if timeout <= 0 {
+		timeout = t.TimeOut
+	}
+	if timeout <= 0 {
+		return t.roundTripLimited(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	res, err := t.roundTripLimited(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// the timeout covers reading the body as well
+	res.Body = &releaseBody{ReadCloser: res.Body, release: cancel}
+	return res, nil
+}
+
+// shouldRetry reports whether req is sent again after the given response or error.
+func shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return retrySafe(req)
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return res.StatusCode >= 500 && retrySafe(req)
+}
+
+// retrySafe reports whether req can be sent again after the server may have processed it.
+// Uploading a document or creating a glossary twice would translate or create it twice.
+func retrySafe(req *http.Request) bool {
+	if req.Method != http.MethodPost {
+		return true
+	}
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	return !strings.HasSuffix(path, "/document") && !strings.HasSuffix(path, "/glossaries")
+}
+
+// retryDelay returns the delay before retry attempt+1, taken from the Retry-After header of res if present.
+func retryDelay(backoff types.RetryBackoff, attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if delay, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			return delay
+		}
+	}
+	if backoff == (types.RetryBackoff{}) {
+		backoff = DefaultRetryBackoff
+	}
+	multiplier := backoff.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(backoff.Initial) * math.Pow(multiplier, float64(attempt))
+	if backoff.Max > 0 && delay > float64(backoff.Max) {
+		delay = float64(backoff.Max)
+	}
+	return applyJitter(time.Duration(delay), backoff.Jitter)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as http date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
@@ -0,0 +1,161 @@
+package deepl
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"3", 3 * time.Second, true},
+		{"Mon, 01 May 2023 12:00:10 GMT", 10 * time.Second, true},
+		{"Mon, 01 May 2023 11:00:00 GMT", 0, true},
+		{"", 0, false},
+		{"-1", 0, false},
+		{"soon", 0, false},
+	}
+	for _, test := range tests {
+		got, ok := parseRetryAfter(test.value, now)
+		if got != test.want || ok != test.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", test.value, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	backoff := DefaultRetryBackoff
+	backoff.Jitter = 0
+	if got := retryDelay(backoff, 2, nil); got != 2560*time.Millisecond {
+		t.Errorf("got %v, want 2.56s", got)
+	}
+	if got := retryDelay(backoff, 20, nil); got != backoff.Max {
+		t.Errorf("got %v, want max %v", got, backoff.Max)
+	}
+	res := &http.Response{Header: http.Header{"Retry-After": {"7"}}}
+	if got := retryDelay(backoff, 0, res); got != 7*time.Second {
+		t.Errorf("got %v, want Retry-After of 7s", got)
+	}
+}
+
+func TestTransport_RetryAfter(t *testing.T) {
+	var requests int32
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"character_count": 1}`))
+	}, WithRetries(3), WithRetryBackoff(time.Minute, time.Minute, 1, 0))
+	start := time.Now()
+	if _, err := translator.GetUsage(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Errorf("got %d requests, want 3", requests)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Retry-After was ignored, took %v", elapsed)
+	}
+}
+
+func TestTransport_RetryUpload(t *testing.T) {
+	var requests int32
+	status := http.StatusTooManyRequests
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		content, _ := io.ReadAll(file)
+		if string(content) != "proton beam" {
+			t.Errorf("got uploaded content %q", content)
+		}
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"document_id": "id", "document_key": "key"}`))
+	}, WithRetries(2), WithRetryBackoff(time.Millisecond, time.Millisecond, 1, 0))
+	ctx := context.Background()
+
+	// a reader that cannot seek, so the content must be buffered for the retry
+	upload := func() error {
+		_, err := translator.UploadDocument(ctx, consts.SourceLangEnglish, consts.TargetLangGerman, io.MultiReader(strings.NewReader("proton beam")), WithFileName("test.txt"))
+		return err
+	}
+	if err := upload(); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, want the upload to be retried after 429", requests)
+	}
+
+	// the document may have been uploaded already, so 5xx is not retried
+	requests = 0
+	status = http.StatusInternalServerError
+	if err := upload(); err == nil {
+		t.Error("expected an error")
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want no retry after 500", requests)
+	}
+}
+
+func TestTransport_AttemptTimeout(t *testing.T) {
+	var requests int32
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.Write([]byte(`{"character_count": 1}`))
+	}, WithRetries(1), WithRetryBackoff(time.Millisecond, time.Millisecond, 1, 0), WithAttemptTimeout(50*time.Millisecond))
+	if _, err := translator.GetUsage(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, want 2", requests)
+	}
+}
+
+func TestTransport_TimeOutPerAttempt(t *testing.T) {
+	var requests int32
+	translator := makeLocalTranslator(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"character_count": 1}`))
+	}, WithTimeOut(300*time.Millisecond))
+	// the Retry-After delay exceeds the timeout, which only limits each attempt
+	if _, err := translator.GetUsage(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, want 2", requests)
+	}
+}
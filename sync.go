@@ -0,0 +1,99 @@
+package deepl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+// SyncGlossary makes sure a glossary with the name and language pair has exactly the given entries
+// and returns it. Glossaries cannot be changed, so if none of the glossaries with the name and
+// language pair has the entries, a new one is created. All other glossaries with the name and
+// language pair are deleted afterwards, so only the returned glossary remains.
+// If deleting an old version fails, the result is returned together with the error.
+func (d *Translator) SyncGlossary(
+	ctx context.Context,
+	name string,
+	source consts.SourceLang,
+	target consts.TargetLang,
+	entries GlossaryEntries,
+) (result types.GlossarySync, err error) {
+	ctx, end := d.observe(ctx, consts.OperationSyncGlossary)
+	defer end(&err)
+	if err := entries.Validate(); err != nil {
+		return result, err
+	}
+	glossaries, err := d.GetGlossaries(ctx)
+	if err != nil {
+		return result, err
+	}
+	versions := glossaryVersions(glossaries, name, source, target)
+	current := -1
+	for i, version := range versions {
+		equal, err := d.hasEntries(ctx, version, entries)
+		if err != nil {
+			return result, err
+		}
+		if equal {
+			current = i
+			break
+		}
+	}
+	if current >= 0 {
+		result.Glossary = versions[current]
+	} else {
+		result.Glossary, err = d.CreateGlossary(ctx, name, source, target, entries)
+		if err != nil {
+			return result, err
+		}
+		result.Created = true
+	}
+	var errs []error
+	for i, version := range versions {
+		if i == current {
+			continue
+		}
+		if err := d.DeleteGlossary(ctx, version.GlossaryID); err != nil {
+			errs = append(errs, fmt.Errorf("delete glossary %s: %w", version.GlossaryID, err))
+			continue
+		}
+		result.Deleted = append(result.Deleted, version.GlossaryID)
+	}
+	return result, errors.Join(errs...)
+}
+
+// glossaryVersions returns the glossaries with the name and language pair, newest first.
+func glossaryVersions(glossaries []types.Glossary, name string, source consts.SourceLang, target consts.TargetLang) []types.Glossary {
+	var versions []types.Glossary
+	for _, glossary := range glossaries {
+		if glossary.Name == name &&
+			strings.EqualFold(string(glossary.SourceLang), string(source)) &&
+			strings.EqualFold(baseLanguage(string(glossary.TargetLang)), baseLanguage(string(target))) {
+			versions = append(versions, glossary)
+		}
+	}
+	sortNewestFirst(versions)
+	return versions
+}
+
+// hasEntries reports whether glossary has exactly the given entries.
+func (d *Translator) hasEntries(ctx context.Context, glossary types.Glossary, entries GlossaryEntries) (bool, error) {
+	if glossary.EntryCount != len(entries.Entries) {
+		return false, nil
+	}
+	current, err := d.GetGlossaryEntries(ctx, glossary.GlossaryID)
+	if err != nil {
+		return false, err
+	}
+	return current.ToTSV() == entries.ToTSV(), nil
+}
+
+// baseLanguage strips the variant of a language code, e.g. EN-US becomes EN.
+func baseLanguage(code string) string {
+	base, _, _ := strings.Cut(code, "-")
+	return base
+}
@@ -0,0 +1,80 @@
+package deepl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hsedr/deepl-golang/consts"
+	"github.com/hsedr/deepl-golang/deepltest"
+	"github.com/hsedr/deepl-golang/types"
+)
+
+func TestTranslator_SyncGlossary(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+	translator, err := NewTranslator("auth_key", WithServerURL(server.ServerURL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	v1 := mustEntries(t, map[string]string{"beam": "Strahl"})
+	first, err := translator.SyncGlossary(ctx, "physics", consts.SourceLangEnglish, consts.TargetLangGerman, v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first.Created || len(first.Deleted) != 0 {
+		t.Errorf("first sync: got %+v, want created glossary", first)
+	}
+
+	unchanged, err := translator.SyncGlossary(ctx, "physics", consts.SourceLangEnglish, consts.TargetLangGerman, v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unchanged.Created || unchanged.Glossary.GlossaryID != first.Glossary.GlossaryID {
+		t.Errorf("unchanged sync: got %+v, want glossary %s", unchanged, first.Glossary.GlossaryID)
+	}
+
+	// other names and language pairs are left alone
+	other, err := translator.CreateGlossary(ctx, "physics", consts.SourceLangEnglish, consts.TargetLangFrench, v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2 := mustEntries(t, map[string]string{"beam": "Strahl", "proton": "Proton"})
+	changed, err := translator.SyncGlossary(ctx, "physics", consts.SourceLangEnglish, consts.TargetLangGerman, v2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed.Created || len(changed.Deleted) != 1 || changed.Deleted[0] != first.Glossary.GlossaryID {
+		t.Errorf("changed sync: got %+v, want new glossary replacing %s", changed, first.Glossary.GlossaryID)
+	}
+	glossaries, err := translator.GetGlossaries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := map[string]bool{}
+	for _, glossary := range glossaries {
+		ids[glossary.GlossaryID] = true
+	}
+	if len(ids) != 2 || !ids[other.GlossaryID] || !ids[changed.Glossary.GlossaryID] {
+		t.Errorf("got glossaries %v, want %s and %s", ids, other.GlossaryID, changed.Glossary.GlossaryID)
+	}
+}
+
+func TestGlossaryVersions(t *testing.T) {
+	now := time.Now()
+	glossaries := []types.Glossary{
+		{GlossaryID: "b", Name: "physics", SourceLang: "en", TargetLang: "de", CreationTime: now},
+		{GlossaryID: "c", Name: "physics", SourceLang: "en", TargetLang: "de", CreationTime: now.Add(time.Second)},
+		{GlossaryID: "a", Name: "physics", SourceLang: "en", TargetLang: "de", CreationTime: now},
+		{GlossaryID: "d", Name: "physics", SourceLang: "en", TargetLang: "fr", CreationTime: now},
+		{GlossaryID: "e", Name: "chemistry", SourceLang: "en", TargetLang: "de", CreationTime: now},
+	}
+	var ids []string
+	for _, version := range glossaryVersions(glossaries, "physics", consts.SourceLangEnglish, consts.TargetLangGerman) {
+		ids = append(ids, version.GlossaryID)
+	}
+	if len(ids) != 3 || ids[0] != "c" || ids[1] != "a" || ids[2] != "b" {
+		t.Errorf("got versions %v, want [c a b]", ids)
+	}
+}
@@ -1,7 +1,10 @@
 package types
 
 import (
+	"context"
 	"io"
+	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/hsedr/deepl-golang/consts"
@@ -32,6 +35,9 @@ type TextTranslateOptions struct {
 
 	//comma-seperated list of xml tags
 	IgnoreTags string `json:"ignore_tags"`
+
+	// additional context that influences the translation but is not translated itself
+	Context string `json:"context"`
 }
 
 type DocumentTranslateOptions struct {
@@ -39,6 +45,25 @@ type DocumentTranslateOptions struct {
 	OutputFile io.Writer
 	Formality  consts.Formality
 	GlossaryID string
+
+	// file extension of the translated document, e.g. "pdf" for docx to pdf
+	OutputFormat string
+
+	// overrides the poll strategy and maximum wait of the Translator
+	PollStrategy PollStrategy
+	MaxPollWait  time.Duration
+
+	// called with every status received while waiting for the translation
+	OnStatus func(DocumentStatus)
+
+	// called with progress events of upload, translation and download
+	OnEvent func(DocumentEvent)
+}
+
+// PollStrategy decides how long to wait before the status of a document translation is checked again.
+// The attempt starts at 1 for the first wait.
+type PollStrategy interface {
+	NextInterval(attempt int, status DocumentStatus) time.Duration
 }
 
 type DocumentHandle struct {
@@ -55,11 +80,57 @@ type DocumentStatus struct {
 }
 
 func (d *DocumentStatus) Ok() bool {
-	return d.ErrorMessage == ""
+	return d.ErrorMessage == "" && d.Status != string(consts.DocumentStatusError)
 }
 
 func (d *DocumentStatus) Done() bool {
-	return d.Status == "done"
+	return d.Status == string(consts.DocumentStatusDone)
+}
+
+// DocumentInput is a document of a batch translation, read from Reader or, if nil, from the file at Path.
+type DocumentInput struct {
+	Path   string
+	Reader io.Reader
+	// defaults to the base name of Path
+	FileName string
+}
+
+type BatchTranslateOptions struct {
+	// maximum number of concurrent uploads, status checks and downloads
+	Concurrency int
+
+	// directory the translated documents are written to as <name>_<target lang>.<ext>
+	OutputDir string
+
+	// opens the writer for a translated document, takes precedence over OutputDir
+	Output func(input DocumentInput, target consts.TargetLang) (io.WriteCloser, error)
+
+	// options applied to every document of the batch, progress and status
+	// functions may be called concurrently
+	DocumentOptions []func(*DocumentTranslateOptions) error
+}
+
+// DocumentResult is the outcome of translating one input of a batch into one target language.
+type DocumentResult struct {
+	Input      DocumentInput
+	TargetLang consts.TargetLang
+	Document   DocumentHandle
+	Status     DocumentStatus
+	// set when the result was written to OutputDir
+	OutputPath string
+	Err        error
+}
+
+// DocumentEvent reports the progress of a document translation.
+type DocumentEvent struct {
+	Type             consts.DocumentEventType
+	Document         DocumentHandle
+	SecondsRemaining int
+	BilledCharacters int
+	// bytes downloaded so far and the total size, -1 if unknown
+	BytesDownloaded int64
+	TotalBytes      int64
+	Err             error
 }
 
 type Translation struct {
@@ -109,6 +180,50 @@ type Glossary struct {
 	EntryCount   int               `json:"entry_count"`
 }
 
+// GlossarySync is the outcome of reconciling a glossary with its desired entries.
+type GlossarySync struct {
+	// the glossary with the desired entries, to be used for translations
+	Glossary Glossary
+	// set if the glossary was created because no glossary had the desired entries
+	Created bool
+	// ids of the older versions that were deleted
+	Deleted []string
+}
+
+// MultilingualGlossaries is the response of listing glossaries with the v3 API.
+type MultilingualGlossaries struct {
+	Glossaries []MultilingualGlossary `json:"glossaries"`
+}
+
+// MultilingualGlossary is a glossary of the v3 API with a dictionary for each of its language pairs.
+type MultilingualGlossary struct {
+	GlossaryID   string                   `json:"glossary_id"`
+	Name         string                   `json:"name"`
+	Dictionaries []GlossaryDictionaryInfo `json:"dictionaries"`
+	CreationTime time.Time                `json:"creation_time"`
+}
+
+// GlossaryDictionaryInfo describes the dictionary of a multilingual glossary for one language pair.
+type GlossaryDictionaryInfo struct {
+	SourceLang consts.SourceLang `json:"source_lang"`
+	TargetLang consts.TargetLang `json:"target_lang"`
+	EntryCount int               `json:"entry_count"`
+}
+
+// GlossaryOptions configure how a glossary is created.
+type GlossaryOptions struct {
+	// format the entries are sent in, "tsv" or "csv", defaults to "tsv"
+	EntriesFormat string
+}
+
+// GlossaryWaitOptions configure how WaitUntilGlossaryReady polls a glossary.
+type GlossaryWaitOptions struct {
+	// time between checks, one second unless set
+	Interval time.Duration
+	// maximum time to wait, zero waits until the context is done
+	MaxWait time.Duration
+}
+
 type AppInfo struct {
 	AppName    string
 	AppVersion string
@@ -121,4 +236,89 @@ type TranslatorOptions struct {
 	AppInfo           AppInfo
 	TimeOut           time.Duration
 	Retries           int
+	// delays between retries, the zero value uses the default backoff
+	RetryBackoff RetryBackoff
+	// timeout of a single attempt of a request, TimeOut limits all attempts together
+	AttemptTimeout time.Duration
+	PollStrategy   PollStrategy
+	MaxPollWait    time.Duration
+	// limits of a single translate request, larger calls are split
+	MaxTextsPerRequest int
+	MaxRequestBytes    int
+	ChunkConcurrency   int
+	// client-side limits of the Transport, zero disables a limit
+	RequestsPerSecond   float64
+	CharactersPerSecond float64
+	MaxInFlight         int
+	// logs every request, auth keys, document keys and texts are redacted unless LogUnredacted is set
+	Logger        *slog.Logger
+	LogUnredacted bool
+	// validate languages before sending, with the interval the supported languages are read again
+	ValidateLanguages       bool
+	LanguageRefreshInterval time.Duration
+	// notified about operations and requests, nil disables it
+	Observer Observer
+	// character budget checked before translations, nil disables the check
+	Quota *QuotaOptions
+	// caches text translations, nil disables caching
+	Cache Cache
+}
+
+// Observer is notified about the operations and requests of a Translator, e.g. to record traces and metrics.
+// Implementations must be safe for concurrent use, see the deeplotel module for OpenTelemetry.
+type Observer interface {
+	// StartOperation is called when a method of the Translator starts. The returned context is used
+	// for the operation and end is called with its error once it finished.
+	StartOperation(ctx context.Context, op consts.Operation) (_ context.Context, end func(error))
+
+	// StartRequest is called before every attempt of a request, starting with attempt 1, and may add headers to req.
+	// The returned context is used for the attempt and end is called with its response or error.
+	StartRequest(ctx context.Context, req *http.Request, attempt int) (_ context.Context, end func(*http.Response, error))
+
+	// TranslatedCharacters is called with the characters of every successful translate request.
+	TranslatedCharacters(ctx context.Context, n int)
+
+	// BilledCharacters is called with the billed characters of every finished document translation.
+	BilledCharacters(ctx context.Context, n int)
+}
+
+// QuotaOptions configure the character budget of a Translator.
+// The budget is the smaller of SoftLimit and the CharacterLimit of the account.
+type QuotaOptions struct {
+	// characters that may be used per billing period, zero only uses the account limit
+	SoftLimit int
+
+	// how often the usage is read from DeepL, zero reads it once
+	RefreshInterval time.Duration
+
+	// only call OnWarning instead of refusing calls exceeding the budget
+	WarnOnly bool
+
+	// called whenever a call would exceed the budget
+	OnWarning func(QuotaWarning)
+}
+
+// QuotaWarning describes a call that would exceed the character budget.
+type QuotaWarning struct {
+	// characters used including the local count since the usage was read
+	Used      int
+	Requested int
+	Limit     int
+}
+
+// RetryBackoff configures the delays between retries of a request. The delay starts at Initial and
+// is multiplied with every retry up to Max. Jitter randomizes each delay by up to the given fraction.
+// A Retry-After header of the response takes precedence.
+type RetryBackoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     float64
+}
+
+// Cache stores translation results by key, see the cache package for implementations.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
 }